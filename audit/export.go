@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// SchemeS3 is the scheme of S3-compatible object storage export sinks
+const SchemeS3 = "s3"
+
+// ExportSink receives pages of Temporal Cloud account audit log records
+type ExportSink interface {
+	// Export writes one page of records ordered by emit time. Pages may be written again after a retry,
+	// so consumers should deduplicate on logId.
+	Export(ctx context.Context, records []*auditlogv1.LogRecord) error
+}
+
+// ParseExportSink returns the export sink for a URI:
+//   - a JSONL file path or file:///path records are appended to
+//   - an http(s) URL each page is POSTed to as JSON lines, with ?token-env=NAME sending a bearer token from $NAME
+//   - s3://bucket/prefix writing one object per page, with ?endpoint= for S3-compatible stores and ?region=
+func ParseExportSink(uri string) (ExportSink, error) {
+	if uri == "" {
+		return nil, fmt.Errorf("an export sink is required")
+	}
+	scheme, rest, found := strings.Cut(uri, "://")
+	if !found {
+		return &JSONLExportSink{Path: uri}, nil
+	}
+	switch scheme {
+	case SchemeFile:
+		return &JSONLExportSink{Path: rest}, nil
+	case SchemeHTTP, SchemeHTTPS:
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, fmt.Errorf("invalid export sink %q: %w", uri, err)
+		}
+		query := u.Query()
+		sink := &HTTPExportSink{TokenEnv: query.Get("token-env")}
+		query.Del("token-env")
+		u.RawQuery = query.Encode()
+		sink.URL = u.String()
+		return sink, nil
+	case SchemeS3:
+		return parseS3Sink(uri)
+	default:
+		return nil, fmt.Errorf("unsupported export sink scheme %q", scheme)
+	}
+}
+
+// encodeRecords encodes records as JSON lines using the Cloud API field names
+func encodeRecords(records []*auditlogv1.LogRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, record := range records {
+		line, err := protojson.Marshal(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode audit log record %s: %w", record.GetLogId(), err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// JSONLExportSink appends records to a local JSON lines file
+type JSONLExportSink struct {
+	Path string
+}
+
+// Export appends the page to the file
+func (s *JSONLExportSink) Export(ctx context.Context, records []*auditlogv1.LogRecord) error {
+	data, err := encodeRecords(records)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open export file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
+
+// HTTPExportSink POSTs each page as JSON lines, e.g. to a SIEM HTTP collector
+type HTTPExportSink struct {
+	URL string
+	// TokenEnv names the environment variable holding a bearer token (optional)
+	TokenEnv string
+	// Client is the HTTP client (optional, defaults to one with a 30s timeout)
+	Client *http.Client
+}
+
+// Export POSTs the page and fails on a non-2xx status
+func (s *HTTPExportSink) Export(ctx context.Context, records []*auditlogv1.LogRecord) error {
+	data, err := encodeRecords(records)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.TokenEnv != "" {
+		token := os.Getenv(s.TokenEnv)
+		if token == "" {
+			return fmt.Errorf("%s is not set", s.TokenEnv)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export audit logs: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("export sink returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// readOnlyPrefixes are the Cloud API methods that do not mutate anything
+var readOnlyPrefixes = []string{"Get", "Validate"}
+
+// IsMutating reports whether a full gRPC method name, e.g. /temporal.api.cloud.cloudservice.v1.CloudService/CreateApiKey,
+// changes state
+func IsMutating(method string) bool {
+	name := path.Base(method)
+	for _, prefix := range readOnlyPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// UnaryClientInterceptor appends a record to the sink for every mutating call, made directly or from an activity.
+// The call's outcome is never changed: the mutation already happened, so a failing sink is reported on stderr.
+func UnaryClientInterceptor(sink Sink, actor string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !IsMutating(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		record := &Record{
+			Actor:     actor,
+			Action:    path.Base(method),
+			Spec:      Redact(toFields(req)),
+			StartedAt: time.Now().UTC(),
+		}
+		if activity.IsActivity(ctx) {
+			info := activity.GetInfo(ctx)
+			record.Activity = info.ActivityType.Name
+			record.WorkflowID = info.WorkflowExecution.ID
+			record.RunID = info.WorkflowExecution.RunID
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		record.CompletedAt = time.Now().UTC()
+		if err != nil {
+			record.Error = err.Error()
+		} else {
+			record.Result = ResultIDs(toFields(reply))
+		}
+
+		if appendErr := sink.Append(context.WithoutCancel(ctx), record); appendErr != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Failed to audit %s: %v\n", record.Action, appendErr)
+		}
+		return err
+	}
+}
+
+// toFields converts a proto message into its JSON field map
+func toFields(message any) map[string]any {
+	m, ok := message.(proto.Message)
+	if !ok {
+		return nil
+	}
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
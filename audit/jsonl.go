@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JSONLSink appends one JSON record per line to a local file readable only by the current user
+type JSONLSink struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// Append writes the record as a single line; O_APPEND keeps concurrent writers' lines intact
+func (s *JSONLSink) Append(ctx context.Context, record *Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Filter selects audit records; empty fields match everything
+type Filter struct {
+	// Actor, Action and WorkflowID match exactly, Action case-insensitively
+	Actor      string
+	Action     string
+	WorkflowID string
+	// Text matches records whose spec or result contains it, e.g. a service account name or ID
+	Text string
+	// Since and Until bound StartedAt
+	Since time.Time
+	Until time.Time
+	// FailedOnly keeps records of failed calls
+	FailedOnly bool
+}
+
+// Matches reports whether the record passes the filter
+func (f *Filter) Matches(record *Record) bool {
+	if f.Actor != "" && record.Actor != f.Actor {
+		return false
+	}
+	if f.Action != "" && !strings.EqualFold(record.Action, f.Action) {
+		return false
+	}
+	if f.WorkflowID != "" && record.WorkflowID != f.WorkflowID {
+		return false
+	}
+	if !f.Since.IsZero() && record.StartedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && record.StartedAt.After(f.Until) {
+		return false
+	}
+	if f.FailedOnly && record.Error == "" {
+		return false
+	}
+	if f.Text != "" {
+		spec, _ := json.Marshal(record.Spec)
+		result, _ := json.Marshal(record.Result)
+		if !strings.Contains(string(spec), f.Text) && !strings.Contains(string(result), f.Text) {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadRecords reads the records of a JSONL audit log matching the filter, oldest first.
+// A missing log has no records.
+func ReadRecords(path string, filter Filter) ([]Record, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid audit record: %w", path, line, err)
+		}
+		if filter.Matches(&record) {
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return records, nil
+}
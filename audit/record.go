@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Redacted replaces secret values in recorded specs and results
+const Redacted = "[REDACTED]"
+
+// Record is one identity mutation performed by the tool
+type Record struct {
+	// Actor is the subject of the Cloud API token that performed the mutation
+	Actor string `json:"actor"`
+	// Action is the Cloud API method, e.g. CreateServiceAccount
+	Action string `json:"action"`
+	// Activity is the activity type that made the call, empty for direct CLI calls
+	Activity string `json:"activity,omitempty"`
+	// WorkflowID and RunID identify the workflow the activity ran in
+	WorkflowID string `json:"workflowId,omitempty"`
+	RunID      string `json:"runId,omitempty"`
+	// Spec is the request with secrets redacted
+	Spec map[string]any `json:"spec,omitempty"`
+	// Result holds the IDs returned by the call, e.g. serviceAccountId and asyncOperationId
+	Result map[string]string `json:"result,omitempty"`
+	// Error is the failure of the call, empty when it succeeded
+	Error string `json:"error,omitempty"`
+	// StartedAt and CompletedAt bracket the call
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// secretKeys are the field names whose values are never recorded, matched ignoring case, '_' and '-'
+var secretKeys = map[string]bool{
+	"token": true, "accesstoken": true, "secret": true, "clientsecret": true, "password": true,
+	"passphrase": true, "apikey": true, "privatekey": true, "credential": true, "credentials": true,
+}
+
+// Redact replaces the values of secret fields, at any depth, with Redacted
+func Redact(fields map[string]any) map[string]any {
+	for key, value := range fields {
+		if isSecretKey(key) {
+			fields[key] = Redacted
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			Redact(v)
+		case []any:
+			for _, item := range v {
+				if m, ok := item.(map[string]any); ok {
+					Redact(m)
+				}
+			}
+		}
+	}
+	return fields
+}
+
+func isSecretKey(key string) bool {
+	return secretKeys[strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))]
+}
+
+// ResultIDs collects the top-level ...Id fields of a response and its async operation ID
+func ResultIDs(fields map[string]any) map[string]string {
+	ids := map[string]string{}
+	for key, value := range fields {
+		if s, ok := value.(string); ok && s != "" && strings.HasSuffix(key, "Id") {
+			ids[key] = s
+		}
+	}
+	if operation, ok := fields["asyncOperation"].(map[string]any); ok {
+		if id, ok := operation["id"].(string); ok && id != "" {
+			ids["asyncOperationId"] = id
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return ids
+}
+
+// ActorFromToken returns the subject claim of a JWT access token or API key without verifying it,
+// or "unknown" when the token carries none
+func ActorFromToken(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) == 3 {
+		if payload, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil {
+			var claims struct {
+				Subject string `json:"sub"`
+			}
+			if json.Unmarshal(payload, &claims) == nil && claims.Subject != "" {
+				return claims.Subject
+			}
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]any
+		want   map[string]any
+	}{
+		{
+			name:   "top-level secret",
+			fields: map[string]any{"token": "t0ken", "keyId": "key-1"},
+			want:   map[string]any{"token": Redacted, "keyId": "key-1"},
+		},
+		{
+			name:   "key spelling is ignored",
+			fields: map[string]any{"api_key": "k", "Client-Secret": "s", "PASSPHRASE": "p", "accessToken": "a"},
+			want:   map[string]any{"api_key": Redacted, "Client-Secret": Redacted, "PASSPHRASE": Redacted, "accessToken": Redacted},
+		},
+		{
+			name: "nested maps and lists",
+			fields: map[string]any{
+				"spec": map[string]any{
+					"name":        "worker",
+					"credentials": map[string]any{"user": "u"},
+					"sinks":       []any{map[string]any{"password": "p", "url": "https://example.com"}, "plain"},
+				},
+			},
+			want: map[string]any{
+				"spec": map[string]any{
+					"name":        "worker",
+					"credentials": Redacted,
+					"sinks":       []any{map[string]any{"password": Redacted, "url": "https://example.com"}, "plain"},
+				},
+			},
+		},
+		{
+			name:   "names containing a secret word are kept",
+			fields: map[string]any{"tokenExpiry": "2025-01-01", "secretName": "db"},
+			want:   map[string]any{"tokenExpiry": "2025-01-01", "secretName": "db"},
+		},
+		{
+			name:   "empty",
+			fields: map[string]any{},
+			want:   map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Redact(tt.fields))
+		})
+	}
+}
+
+func TestActorFromToken(t *testing.T) {
+	jwt := func(payload string) string {
+		return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2lnbmF0dXJl"
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		actor string
+	}{
+		{name: "login token", token: jwt(`{"sub":"alice@example.com","iss":"https://login.tmprl.cloud/"}`), actor: "alice@example.com"},
+		{name: "service account key", token: jwt(`{"sub":"sa-123"}`), actor: "sa-123"},
+		{name: "no subject", token: jwt(`{"iss":"https://login.tmprl.cloud/"}`), actor: "unknown"},
+		{name: "payload is not json", token: jwt(`not json`), actor: "unknown"},
+		{name: "payload is not base64", token: "header.!!!.signature", actor: "unknown"},
+		{name: "not a jwt", token: "tmprl_opaque_key", actor: "unknown"},
+		{name: "empty", token: "", actor: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.actor, ActorFromToken(tt.token))
+		})
+	}
+}
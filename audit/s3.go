@@ -0,0 +1,169 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+)
+
+// S3ExportSink writes each page as a JSON lines object to S3 or an S3-compatible store.
+// Credentials come from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+type S3ExportSink struct {
+	Bucket string
+	Prefix string
+	// Region signs the requests (optional, defaults to AWS_REGION or us-east-1)
+	Region string
+	// Endpoint is an S3-compatible endpoint addressed path-style, e.g. https://minio:9000 (optional)
+	Endpoint string
+	// Client is the HTTP client (optional, defaults to one with a 30s timeout)
+	Client *http.Client
+}
+
+func parseS3Sink(uri string) (*S3ExportSink, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid export sink %q, expected s3://bucket/prefix", uri)
+	}
+	sink := &S3ExportSink{
+		Bucket:   u.Host,
+		Prefix:   strings.Trim(u.Path, "/"),
+		Region:   u.Query().Get("region"),
+		Endpoint: strings.TrimSuffix(u.Query().Get("endpoint"), "/"),
+	}
+	if sink.Region == "" {
+		sink.Region = os.Getenv("AWS_REGION")
+	}
+	if sink.Region == "" {
+		sink.Region = "us-east-1"
+	}
+	return sink, nil
+}
+
+// Export PUTs the page under {prefix}/{yyyy}/{mm}/{dd}/{emitTime}-{logId}.jsonl keyed by its first record,
+// so a retried page overwrites the same object
+func (s *S3ExportSink) Export(ctx context.Context, records []*auditlogv1.LogRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	data, err := encodeRecords(records)
+	if err != nil {
+		return err
+	}
+	first := records[0]
+	emitted := first.GetEmitTime().AsTime().UTC()
+	key := fmt.Sprintf("%s/%s-%s.jsonl", emitted.Format("2006/01/02"), emitted.Format("20060102T150405.000000000Z"), first.GetLogId())
+	if s.Prefix != "" {
+		key = s.Prefix + "/" + key
+	}
+
+	endpoint := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.Bucket, s.Region, uriEncode(key))
+	if s.Endpoint != "" {
+		endpoint = fmt.Sprintf("%s/%s/%s", s.Endpoint, s.Bucket, uriEncode(key))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create S3 request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if err := s.sign(req, data, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload audit logs to S3: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("S3 returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header
+func (s *S3ExportSink) sign(req *http.Request, payload []byte, now time.Time) error {
+	accessKey, secretKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for the S3 sink")
+	}
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if token := os.Getenv("AWS_SESSION_TOKEN"); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, req.URL.EscapedPath(), req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+	scope := date + "/" + s.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	for _, part := range []string{s.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+	return nil
+}
+
+// uriEncode escapes every byte of an object key except unreserved characters and '/', as SigV4 requires
+func uriEncode(key string) string {
+	var b strings.Builder
+	for _, c := range []byte(key) {
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
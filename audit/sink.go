@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Sink schemes
+const (
+	SchemeFile  = "file"
+	SchemeHTTP  = "http"
+	SchemeHTTPS = "https"
+)
+
+// DefaultLogFile is the JSONL audit log name inside the state directory
+const DefaultLogFile = "audit.jsonl"
+
+// Sink receives audit records
+type Sink interface {
+	// Append records one mutation
+	Append(ctx context.Context, record *Record) error
+}
+
+// ParseSink returns the sink for a URI: a JSONL file path, file:///path, or an http(s) URL records are POSTed to
+func ParseSink(uri string) (Sink, error) {
+	if uri == "" {
+		return nil, fmt.Errorf("an audit sink is required")
+	}
+	scheme, rest, found := strings.Cut(uri, "://")
+	if !found {
+		return &JSONLSink{Path: uri}, nil
+	}
+	switch scheme {
+	case SchemeFile:
+		return &JSONLSink{Path: rest}, nil
+	case SchemeHTTP, SchemeHTTPS:
+		if _, err := url.Parse(uri); err != nil {
+			return nil, fmt.Errorf("invalid audit sink %q: %w", uri, err)
+		}
+		return &HTTPSink{URL: uri}, nil
+	default:
+		return nil, fmt.Errorf("unsupported audit sink scheme %q", scheme)
+	}
+}
+
+// HTTPSink POSTs each record as JSON, e.g. to a SIEM collector
+type HTTPSink struct {
+	URL string
+	// Client is the HTTP client (optional, defaults to one with a 10s timeout)
+	Client *http.Client
+}
+
+// Append POSTs the record and fails on a non-2xx status
+func (s *HTTPSink) Append(ctx context.Context, record *Record) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create audit request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send audit record: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit sink returned %s", resp.Status)
+	}
+	return nil
+}
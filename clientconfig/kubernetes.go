@@ -0,0 +1,341 @@
+package clientconfig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FormatEnvConfig writes the temporal.toml envconfig profiles
+	FormatEnvConfig = "envconfig"
+	// FormatSecret writes a plain Kubernetes Secret holding the API key
+	FormatSecret = "secret"
+	// FormatSealedSecret writes a Bitnami SealedSecret holding the API key, sealed with kubeseal
+	FormatSealedSecret = "sealed-secret"
+	// FormatExternalSecret writes an ExternalSecret pulling the API key from a secret store
+	FormatExternalSecret = "external-secret"
+	// FormatKustomize writes a kustomize overlay wiring the credentials into a Deployment
+	FormatKustomize = "kustomize"
+)
+
+// Formats lists every supported output format
+var Formats = []string{FormatEnvConfig, FormatSecret, FormatSealedSecret, FormatExternalSecret, FormatKustomize}
+
+const (
+	// KubernetesDir is the directory below the output path Kubernetes manifests are written to
+	KubernetesDir = "kubernetes"
+
+	EnvApiKey    = "TEMPORAL_API_KEY"
+	EnvAddress   = "TEMPORAL_ADDRESS"
+	EnvNamespace = "TEMPORAL_NAMESPACE"
+
+	secretFile         = "secret.yaml"
+	sealedSecretFile   = "sealed-secret.yaml"
+	externalSecretFile = "external-secret.yaml"
+	configMapFile      = "configmap.yaml"
+	deploymentPatch    = "deployment-patch.yaml"
+	kustomizationFile  = "kustomization.yaml"
+)
+
+// OutputOptions selects which files are generated for created credentials
+type OutputOptions struct {
+	// Formats are the output formats to write (optional, defaults to envconfig)
+	Formats []string `json:"formats,omitempty"`
+	// Kubernetes configures the Kubernetes formats
+	Kubernetes *KubernetesOptions `json:"kubernetes,omitempty"`
+}
+
+// KubernetesOptions configures the generated Kubernetes manifests
+type KubernetesOptions struct {
+	// Namespace is the Kubernetes namespace of the manifests (optional)
+	Namespace string `json:"namespace,omitempty"`
+	// SecretName is the name of the Secret holding TEMPORAL_API_KEY (optional, defaults to temporal-credentials)
+	SecretName string `json:"secretName,omitempty"`
+	// ConfigMapName is the name of the ConfigMap holding TEMPORAL_ADDRESS (optional, defaults to temporal-config)
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// Deployment is the Deployment the kustomize overlay patches (required for kustomize)
+	Deployment string `json:"deployment,omitempty"`
+	// Container is the container receiving the env vars (optional, defaults to the Deployment name)
+	Container string `json:"container,omitempty"`
+	// SecretStore is the external-secrets store the ExternalSecret reads from (required for external-secret)
+	SecretStore string `json:"secretStore,omitempty"`
+	// SecretStoreKind is SecretStore or ClusterSecretStore (optional, defaults to ClusterSecretStore)
+	SecretStoreKind string `json:"secretStoreKind,omitempty"`
+	// RemoteKey is the path of the API key in the secret store (required for external-secret)
+	RemoteKey string `json:"remoteKey,omitempty"`
+	// RemoteProperty is the property of the remote secret holding the key (optional)
+	RemoteProperty string `json:"remoteProperty,omitempty"`
+	// KubesealCert is the sealed-secrets controller certificate used by kubeseal (required for sealed-secret)
+	KubesealCert string `json:"kubesealCert,omitempty"`
+}
+
+// Has reports whether the format is selected; envconfig is selected when no format is given
+func (o *OutputOptions) Has(format string) bool {
+	if o == nil || len(o.Formats) == 0 {
+		return format == FormatEnvConfig
+	}
+	return slices.Contains(o.Formats, format)
+}
+
+// HasKubernetes reports whether any Kubernetes format is selected
+func (o *OutputOptions) HasKubernetes() bool {
+	return o.Has(FormatSecret) || o.Has(FormatSealedSecret) || o.Has(FormatExternalSecret) || o.Has(FormatKustomize)
+}
+
+// Validate checks the formats are known and their Kubernetes options are set
+func (o *OutputOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	for _, format := range o.Formats {
+		if !slices.Contains(Formats, format) {
+			return fmt.Errorf("unknown output format %q, expected one of %v", format, Formats)
+		}
+	}
+	secrets := 0
+	for _, format := range []string{FormatSecret, FormatSealedSecret, FormatExternalSecret} {
+		if o.Has(format) {
+			secrets++
+		}
+	}
+	if secrets > 1 {
+		return fmt.Errorf("choose only one of %s, %s or %s", FormatSecret, FormatSealedSecret, FormatExternalSecret)
+	}
+	k := o.kubernetes()
+	if o.Has(FormatSealedSecret) && k.KubesealCert == "" {
+		return fmt.Errorf("a kubeseal certificate is required for %s", FormatSealedSecret)
+	}
+	if o.Has(FormatExternalSecret) && (k.SecretStore == "" || k.RemoteKey == "") {
+		return fmt.Errorf("a secret store and remote key are required for %s", FormatExternalSecret)
+	}
+	if o.Has(FormatKustomize) && k.Deployment == "" {
+		return fmt.Errorf("a deployment is required for %s", FormatKustomize)
+	}
+	return nil
+}
+
+// kubernetes returns the Kubernetes options with defaults applied
+func (o *OutputOptions) kubernetes() KubernetesOptions {
+	var k KubernetesOptions
+	if o != nil && o.Kubernetes != nil {
+		k = *o.Kubernetes
+	}
+	if k.SecretName == "" {
+		k.SecretName = "temporal-credentials"
+	}
+	if k.ConfigMapName == "" {
+		k.ConfigMapName = "temporal-config"
+	}
+	if k.Container == "" {
+		k.Container = k.Deployment
+	}
+	if k.SecretStoreKind == "" {
+		k.SecretStoreKind = "ClusterSecretStore"
+	}
+	return k
+}
+
+type objectMeta struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+type manifest struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   objectMeta  `yaml:"metadata"`
+	Type       string      `yaml:"type,omitempty"`
+	StringData interface{} `yaml:"stringData,omitempty"`
+	Data       interface{} `yaml:"data,omitempty"`
+	Spec       interface{} `yaml:"spec,omitempty"`
+}
+
+// RenderSecret renders a plain Secret holding the API key
+func (o *OutputOptions) RenderSecret(apiKey string) ([]byte, error) {
+	k := o.kubernetes()
+	return marshal(manifest{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   objectMeta{Name: k.SecretName, Namespace: k.Namespace},
+		Type:       "Opaque",
+		StringData: map[string]string{EnvApiKey: apiKey},
+	})
+}
+
+// WriteApiKeyManifests writes the Secret or SealedSecret for the API key into {outputPath}/kubernetes
+// and returns the files written. Nothing is written when neither format is selected.
+func (o *OutputOptions) WriteApiKeyManifests(outputPath string, apiKey string) ([]string, error) {
+	if !o.Has(FormatSecret) && !o.Has(FormatSealedSecret) {
+		return nil, nil
+	}
+	secret, err := o.RenderSecret(apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render secret: %w", err)
+	}
+
+	fileName, content := secretFile, secret
+	if o.Has(FormatSealedSecret) {
+		if content, err = seal(secret, o.kubernetes().KubesealCert); err != nil {
+			return nil, err
+		}
+		fileName = sealedSecretFile
+	}
+
+	file, err := writeKubernetesFile(outputPath, fileName, content, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return []string{file}, nil
+}
+
+// seal encrypts the Secret with kubeseal so only the cluster's sealed-secrets controller can read it
+func seal(secret []byte, cert string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("kubeseal", "--cert", cert, "--format", "yaml")
+	cmd.Stdin = bytes.NewReader(secret)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("kubeseal failed: %w: %s", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// WriteConfigManifests writes the ConfigMap, ExternalSecret and kustomize overlay selected by the formats
+// into {outputPath}/kubernetes and returns the files written
+func (o *OutputOptions) WriteConfigManifests(outputPath string, profile Profile) ([]string, error) {
+	if !o.Has(FormatExternalSecret) && !o.Has(FormatKustomize) {
+		return nil, nil
+	}
+	k := o.kubernetes()
+	var files []string
+	write := func(name string, m interface{}) error {
+		content, err := marshal(m)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", name, err)
+		}
+		file, err := writeKubernetesFile(outputPath, name, content, 0o644)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+		return nil
+	}
+
+	if o.Has(FormatExternalSecret) {
+		remoteRef := map[string]string{"key": k.RemoteKey}
+		if k.RemoteProperty != "" {
+			remoteRef["property"] = k.RemoteProperty
+		}
+		if err := write(externalSecretFile, manifest{
+			APIVersion: "external-secrets.io/v1beta1",
+			Kind:       "ExternalSecret",
+			Metadata:   objectMeta{Name: k.SecretName, Namespace: k.Namespace},
+			Spec: map[string]interface{}{
+				"refreshInterval": "1h",
+				"secretStoreRef":  map[string]string{"name": k.SecretStore, "kind": k.SecretStoreKind},
+				"target":          map[string]string{"name": k.SecretName},
+				"data": []map[string]interface{}{
+					{"secretKey": EnvApiKey, "remoteRef": remoteRef},
+				},
+			},
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.Has(FormatKustomize) {
+		if err := write(configMapFile, manifest{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Metadata:   objectMeta{Name: k.ConfigMapName, Namespace: k.Namespace},
+			Data:       map[string]string{EnvAddress: profile.Address, EnvNamespace: profile.Namespace},
+		}); err != nil {
+			return nil, err
+		}
+
+		env := []map[string]interface{}{
+			{"name": EnvApiKey, "valueFrom": map[string]interface{}{
+				"secretKeyRef": map[string]string{"name": k.SecretName, "key": EnvApiKey}}},
+			{"name": EnvAddress, "valueFrom": map[string]interface{}{
+				"configMapKeyRef": map[string]string{"name": k.ConfigMapName, "key": EnvAddress}}},
+			{"name": EnvNamespace, "valueFrom": map[string]interface{}{
+				"configMapKeyRef": map[string]string{"name": k.ConfigMapName, "key": EnvNamespace}}},
+		}
+		if err := write(deploymentPatch, manifest{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Metadata:   objectMeta{Name: k.Deployment, Namespace: k.Namespace},
+			Spec: map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []map[string]interface{}{
+							{"name": k.Container, "env": env},
+						},
+					},
+				},
+			},
+		}); err != nil {
+			return nil, err
+		}
+
+		resources := []string{configMapFile}
+		switch {
+		case o.Has(FormatSealedSecret):
+			resources = append(resources, sealedSecretFile)
+		case o.Has(FormatSecret):
+			resources = append(resources, secretFile)
+		case o.Has(FormatExternalSecret):
+			resources = append(resources, externalSecretFile)
+		}
+		kustomization := map[string]interface{}{
+			"apiVersion": "kustomize.config.k8s.io/v1beta1",
+			"kind":       "Kustomization",
+			"resources":  resources,
+			"patches":    []map[string]string{{"path": deploymentPatch}},
+		}
+		if k.Namespace != "" {
+			kustomization["namespace"] = k.Namespace
+		}
+		if err := write(kustomizationFile, kustomization); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// marshal renders YAML with the two space indentation used by kubectl
+func marshal(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func writeKubernetesFile(outputPath string, name string, content []byte, perm os.FileMode) (string, error) {
+	dir := filepath.Join(outputPath, KubernetesDir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create kubernetes output path: %w", err)
+	}
+	file := filepath.Join(dir, name)
+	if err := os.WriteFile(file, content, perm); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(file, perm); err != nil {
+		return "", fmt.Errorf("failed to set the mode of %s: %w", name, err)
+	}
+	return file, nil
+}
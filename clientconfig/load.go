@@ -0,0 +1,64 @@
+package clientconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+type fileProfile struct {
+	Address   string `toml:"address"`
+	Namespace string `toml:"namespace"`
+	ApiKey    string `toml:"api_key"`
+	TLS       *struct {
+		Disabled         bool   `toml:"disabled"`
+		ServerName       string `toml:"server_name"`
+		ServerCACertPath string `toml:"server_ca_cert_path"`
+		ClientCertPath   string `toml:"client_cert_path"`
+		ClientKeyPath    string `toml:"client_key_path"`
+	} `toml:"tls"`
+}
+
+// DefaultConfigFile returns TEMPORAL_CONFIG_FILE, or the temporal.toml the Temporal CLI reads by default
+func DefaultConfigFile() string {
+	if file := os.Getenv("TEMPORAL_CONFIG_FILE"); file != "" {
+		return file
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return FileName
+	}
+	return filepath.Join(dir, "temporalio", FileName)
+}
+
+// LoadProfile reads the named profile from an envconfig TOML file and returns the profile's api_key with it
+func LoadProfile(file string, name string) (*Profile, string, error) {
+	var config struct {
+		Profile map[string]fileProfile `toml:"profile"`
+	}
+	if _, err := toml.DecodeFile(file, &config); err != nil {
+		return nil, "", fmt.Errorf("failed to read client config %s: %w", file, err)
+	}
+	p, ok := config.Profile[name]
+	if !ok {
+		return nil, "", fmt.Errorf("profile %s not found in %s", name, file)
+	}
+
+	profile := &Profile{
+		Name:      name,
+		Address:   p.Address,
+		Namespace: p.Namespace,
+	}
+	if p.TLS != nil {
+		profile.TLS = &TLS{
+			Disabled:         p.TLS.Disabled,
+			ServerName:       p.TLS.ServerName,
+			ServerCACertPath: p.TLS.ServerCACertPath,
+			ClientCertPath:   p.TLS.ClientCertPath,
+			ClientKeyPath:    p.TLS.ClientKeyPath,
+		}
+	}
+	return profile, p.ApiKey, nil
+}
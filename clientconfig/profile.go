@@ -0,0 +1,148 @@
+package clientconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileName is the name of the client configuration file written to the output path
+const FileName = "temporal.toml"
+
+// LocalProfileName is the profile pointing at the persistent local TemporalService
+const LocalProfileName = "local"
+
+// Profile is a single Temporal client envconfig profile, selected with TEMPORAL_PROFILE
+type Profile struct {
+	// Name is the profile name, e.g. 'billing-a1b2c' or 'local'
+	Name string `json:"name"`
+	// Address is the frontend host:port
+	Address string `json:"address"`
+	// Namespace is the namespace clients connect to
+	Namespace string `json:"namespace"`
+	// ApiKeyFile is the plaintext file holding the API key
+	ApiKeyFile string `json:"apiKeyFile,omitempty"`
+	// ApiKey is the key read from ApiKeyFile by ReadApiKey and written as api_key; it never leaves the process
+	ApiKey string `json:"-"`
+	// TLS configures transport security, nil leaves the client defaults
+	TLS *TLS `json:"tls,omitempty"`
+}
+
+// TLS is the tls table of an envconfig profile
+type TLS struct {
+	Disabled         bool   `json:"disabled"`
+	ServerName       string `json:"serverName,omitempty"`
+	ServerCACertPath string `json:"serverCACertPath,omitempty"`
+	ClientCertPath   string `json:"clientCertPath,omitempty"`
+	ClientKeyPath    string `json:"clientKeyPath,omitempty"`
+}
+
+var profileNameInvalid = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// ProfileName turns a namespace id such as 'billing.a1b2c' into a valid profile name such as 'billing-a1b2c'.
+// The account id stays in the name so profiles of namespaces with the same name in different accounts never collide.
+func ProfileName(namespace string) string {
+	return strings.Trim(profileNameInvalid.ReplaceAllString(namespace, "-"), "-")
+}
+
+// CloudProfile builds an API key profile for a Temporal Cloud namespace
+func CloudProfile(name string, namespace string, address string, apiKeyFile string) Profile {
+	return Profile{
+		Name:       name,
+		Address:    address,
+		Namespace:  namespace,
+		ApiKeyFile: apiKeyFile,
+		TLS:        &TLS{},
+	}
+}
+
+// ReadApiKey reads the API key from the profile's ApiKeyFile so it is written as the profile's api_key
+func (p *Profile) ReadApiKey() error {
+	if p.ApiKeyFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(p.ApiKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read api key file for profile %s: %w", p.Name, err)
+	}
+	p.ApiKey = strings.TrimSpace(string(data))
+	return nil
+}
+
+// LocalProfile builds a plaintext profile for the persistent local TemporalService
+func LocalProfile(address string, namespace string) Profile {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return Profile{
+		Name:      LocalProfileName,
+		Address:   address,
+		Namespace: namespace,
+		TLS:       &TLS{Disabled: true},
+	}
+}
+
+// Render writes the profiles in the TOML format loaded by the Temporal CLI and the SDK envconfig packages.
+// Profiles are emitted in name order so regenerating the file gives a stable diff.
+func Render(profiles []Profile) string {
+	sorted := append([]Profile(nil), profiles...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var b strings.Builder
+	b.WriteString("# Generated by temporal-jumpstart-operations.\n")
+	b.WriteString("# Select a profile with TEMPORAL_PROFILE=<name> or --profile <name>.\n")
+	for _, p := range sorted {
+		fmt.Fprintf(&b, "\n[profile.%s]\n", p.Name)
+		fmt.Fprintf(&b, "address = %s\n", strconv.Quote(p.Address))
+		fmt.Fprintf(&b, "namespace = %s\n", strconv.Quote(p.Namespace))
+		if p.ApiKey != "" {
+			fmt.Fprintf(&b, "# api_key is the key in %s, regenerate this file when the key is rotated\n", p.ApiKeyFile)
+			fmt.Fprintf(&b, "api_key = %s\n", strconv.Quote(p.ApiKey))
+		} else if p.ApiKeyFile != "" {
+			fmt.Fprintf(&b, "# Provide the API key with:\n")
+			fmt.Fprintf(&b, "#   export TEMPORAL_API_KEY=\"$(cat %s)\"\n", p.ApiKeyFile)
+		}
+		if p.TLS != nil {
+			fmt.Fprintf(&b, "\n[profile.%s.tls]\n", p.Name)
+			fmt.Fprintf(&b, "disabled = %t\n", p.TLS.Disabled)
+			writeOptional(&b, "server_name", p.TLS.ServerName)
+			writeOptional(&b, "server_ca_cert_path", p.TLS.ServerCACertPath)
+			writeOptional(&b, "client_cert_path", p.TLS.ClientCertPath)
+			writeOptional(&b, "client_key_path", p.TLS.ClientKeyPath)
+		}
+	}
+	return b.String()
+}
+
+func writeOptional(b *strings.Builder, key string, value string) {
+	if value != "" {
+		fmt.Fprintf(b, "%s = %s\n", key, strconv.Quote(value))
+	}
+}
+
+// Write renders the profiles into {outputPath}/temporal.toml and returns the file name
+func Write(outputPath string, profiles []Profile) (string, error) {
+	seen := map[string]bool{}
+	for _, p := range profiles {
+		if p.Name == "" {
+			return "", fmt.Errorf("profile name is required")
+		}
+		if seen[p.Name] {
+			return "", fmt.Errorf("profile %s is defined more than once", p.Name)
+		}
+		seen[p.Name] = true
+	}
+
+	if err := os.MkdirAll(outputPath, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create output path: %w", err)
+	}
+	configFile := filepath.Join(outputPath, FileName)
+	if err := os.WriteFile(configFile, []byte(Render(profiles)), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write client config: %w", err)
+	}
+	return configFile, nil
+}
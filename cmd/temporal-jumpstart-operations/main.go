@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"temporal-jumpstart-operations/commands/dashboards"
 	"temporal-jumpstart-operations/commands/operations"
+	"temporal-jumpstart-operations/commands/worker"
 
 	"github.com/spf13/cobra"
 )
@@ -18,10 +20,15 @@ var rootCmd = &cobra.Command{
 func init() {
 	// Add command groups
 	rootCmd.AddCommand(operations.NewOperationsCommand())
+	rootCmd.AddCommand(operations.NewServerCommand())
+	rootCmd.AddCommand(worker.NewWorkerCommand())
+	rootCmd.AddCommand(dashboards.NewDashboardsCommand())
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	operations.ShutdownTelemetry()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
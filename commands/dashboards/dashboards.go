@@ -0,0 +1,121 @@
+package dashboards
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"temporal-jumpstart-operations/dashboards"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Render command flags
+	templatePath string
+	formats      []string
+	outputDir    string
+	name         string
+	options      dashboards.Options
+)
+
+// NewDashboardsCommand creates and returns the dashboards command with its subcommands
+func NewDashboardsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dashboards",
+		Short: "Generate Temporal SDK metrics dashboards",
+		Long:  `Generate Datadog and Grafana dashboards for the Temporal SDK metrics from a single template.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newRenderCommand())
+
+	return cmd
+}
+
+// newRenderCommand creates the dashboards render subcommand
+func newRenderCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render dashboards from the Datadog template",
+		Long: `Render dashboards from a Datadog dashboard template, the bundled Temporal SDK dashboard by default.
+
+Every query is scoped by service, namespace and task_queue variables defaulting to the given filters,
+and every metric is checked against the metrics the Go SDK emits before anything is written.
+Files are written as <output-dir>/<name>-<format>.json.`,
+		Example: `  temporal-jumpstart-operations dashboards render --service billing-worker --namespace billing.a1b2c -o dashboards/
+  temporal-jumpstart-operations dashboards render --format grafana --metric-prefix myapp_temporal_ -o -`,
+		RunE: runRender,
+	}
+
+	cmd.Flags().StringVar(&templatePath, "template", "", "Datadog dashboard JSON to render (defaults to the bundled Temporal SDK dashboard)")
+	cmd.Flags().StringSliceVar(&formats, "format", dashboards.Formats, "Output formats: "+strings.Join(dashboards.Formats, ", "))
+	cmd.Flags().StringVarP(&outputDir, "output-dir", "o", ".", "Directory to write the dashboards to, - for stdout with a single format")
+	cmd.Flags().StringVar(&name, "name", "temporal-sdk", "File name prefix of the rendered dashboards")
+	cmd.Flags().StringVar(&options.Title, "title", "", "Dashboard title (defaults to the template title)")
+	cmd.Flags().StringVar(&options.Service, "service", "", "Default service filter (defaults to all)")
+	cmd.Flags().StringVar(&options.Namespace, "namespace", "", "Default namespace filter (defaults to all)")
+	cmd.Flags().StringVar(&options.TaskQueue, "task-queue", "", "Default task queue filter (defaults to all)")
+	cmd.Flags().StringVar(&options.MetricPrefix, "metric-prefix", dashboards.SDKMetricPrefix, "Prefix the metrics arrive with, replacing the SDK's "+dashboards.SDKMetricPrefix)
+	cmd.Flags().StringVar(&options.Datasource, "grafana-datasource", "", "Grafana Prometheus datasource uid (defaults to a datasource variable)")
+	cmd.Flags().StringVar(&options.HistogramSuffix, "grafana-histogram-suffix", "_seconds", "Suffix Prometheus adds to SDK latency histograms, empty for the tally Prometheus reporter")
+
+	return cmd
+}
+
+// runRender validates the template and writes one dashboard per format
+func runRender(cmd *cobra.Command, args []string) error {
+	for _, format := range formats {
+		if format != dashboards.FormatDatadog && format != dashboards.FormatGrafana {
+			return fmt.Errorf("unsupported format %q, expected one of %s", format, strings.Join(dashboards.Formats, ", "))
+		}
+	}
+	if outputDir == "-" && len(formats) != 1 {
+		return fmt.Errorf("--output-dir - needs exactly one --format")
+	}
+
+	template, err := dashboards.LoadTemplate(templatePath)
+	if err != nil {
+		return err
+	}
+	if err := dashboards.Validate(template); err != nil {
+		return err
+	}
+
+	for _, format := range formats {
+		var dashboard interface{}
+		switch format {
+		case dashboards.FormatDatadog:
+			dashboard, err = dashboards.RenderDatadog(template, options)
+		case dashboards.FormatGrafana:
+			dashboard, err = dashboards.RenderGrafana(template, options)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to render %s dashboard: %w", format, err)
+		}
+
+		data, err := json.MarshalIndent(dashboard, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s dashboard: %w", format, err)
+		}
+		data = append(data, '\n')
+
+		if outputDir == "-" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		path := filepath.Join(outputDir, fmt.Sprintf("%s-%s.json", name, format))
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s dashboard: %w", format, err)
+		}
+		fmt.Printf("📊 Wrote %s dashboard to %s\n", format, path)
+	}
+
+	fmt.Printf("✅ %d SDK metrics validated\n", len(dashboards.MetricNames(template)))
+	return nil
+}
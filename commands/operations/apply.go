@@ -0,0 +1,162 @@
+package operations
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"temporal-jumpstart-operations/desiredstate"
+	"temporal-jumpstart-operations/workflows"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Apply/plan command flags
+	desiredStateFile string
+)
+
+// NewApplyCommand creates the apply command which converges Temporal Cloud to a desired state file
+func NewApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply a desired state file to Temporal Cloud",
+		Long: `Compute the changes needed to converge Temporal Cloud to the service accounts, access and API keys
+declared in a desired state file, print them and apply them through the ReconcileDesiredState workflow.
+
+Example desired state file:
+
+  serviceAccounts:
+    - name: billing-worker
+      accountRole: read
+      namespaces:
+        billing.a1b2c: write
+      apiKeys:
+        - name: billing-worker_key
+          duration: 90d
+          outputPath: ./secrets/billing-worker
+
+Service accounts that exist in Cloud but are not declared are reported and left untouched.`,
+		RunE: runApply,
+	}
+
+	cmd.Flags().StringVarP(&desiredStateFile, "file", "f", "", "Desired state file (required)")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// NewPlanCommand creates the plan command which prints the changes apply would make
+func NewPlanCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Show the changes needed to apply a desired state file",
+		Long:  `Compute and print the changes needed to converge Temporal Cloud to a desired state file without applying them.`,
+		RunE:  runPlan,
+	}
+
+	cmd.Flags().StringVarP(&desiredStateFile, "file", "f", "", "Desired state file (required)")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// runPlan loads the desired state file and prints the plan
+func runPlan(cmd *cobra.Command, args []string) error {
+	p, err := loadPolicy()
+	if err != nil {
+		return err
+	}
+	desired, err := desiredstate.LoadWithPolicy(desiredStateFile, p)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔗 Connecting to Temporal Cloud...\n")
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	observed, err := desiredstate.Observe(cmd.Context(), cloudService)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	desiredstate.ComputePlan(desired, observed).Print(os.Stdout)
+	return nil
+}
+
+// runApply loads the desired state file, prints the plan and runs the reconciliation workflow
+func runApply(cmd *cobra.Command, args []string) error {
+	p, err := configuredPolicy()
+	if err != nil {
+		return err
+	}
+	desired, err := desiredstate.LoadWithPolicy(desiredStateFile, p)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔗 Connecting to Temporal Cloud...\n")
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	observed, err := desiredstate.Observe(ctx, cloudService)
+	if err != nil {
+		return err
+	}
+
+	plan := desiredstate.ComputePlan(desired, observed)
+	fmt.Println()
+	plan.Print(os.Stdout)
+
+	if len(plan.Conflicts) > 0 {
+		return fmt.Errorf("plan has %d conflict(s), resolve them before applying", len(plan.Conflicts))
+	}
+	if plan.IsEmpty() {
+		return nil
+	}
+
+	request := &workflows.ReconcileDesiredStateRequest{
+		Plan: plan,
+	}
+	if len(plan.Privileged()) > 0 {
+		if request.ApprovalRequest, err = approvalRequest(ctx, cloudService); err != nil {
+			return err
+		}
+	}
+	workflowID, err := requestWorkflowID("reconcile-desired-state", request)
+	if err != nil {
+		return err
+	}
+	var result workflows.ReconcileDesiredStateResult
+	if err := runOperationsWorkflow(ctx, cloudService, workflowID, workflows.ReconcileDesiredState, request, &result); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n🎉 Desired state applied successfully!\n")
+	for _, name := range sortedKeys(result.ServiceAccountIds) {
+		fmt.Printf("   Service Account: %s (ID: %s)\n", name, result.ServiceAccountIds[name])
+	}
+	for _, name := range sortedKeys(result.ApiKeyIds) {
+		fmt.Printf("   API Key: %s (ID: %s)\n", name, result.ApiKeyIds[name])
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in lexical order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
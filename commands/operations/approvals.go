@@ -0,0 +1,229 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"temporal-jumpstart-operations/policy"
+	"temporal-jumpstart-operations/workflows"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/api/workflowservice/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+)
+
+var (
+	// Approval decision flags
+	approver        string
+	approvalComment string
+)
+
+// NewApprovalsCommand creates and returns the approvals command with its subcommands
+func NewApprovalsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approvals",
+		Short: "Review privileged operations waiting for approval",
+		Long: `On the Temporal Cloud accounts the policy file lists under approvals.accounts, creating a service account
+with the admin or owner account role, applying a plan that grants one, and deleting a service account wait
+until enough other people approve. The operations worker decides from its own policy and Cloud account; when
+the policy file here marks the account as production and the worker's does not, the operation fails instead
+of running unapproved. Nobody can approve their own operation, and one rejection or the timeout rejects it.
+
+  approvals:
+    accounts: [a1b2c]
+    approvers: 2
+    timeout: 24h
+    allowedApprovers: [alice@example.com, bob@example.com, carol@example.com]
+
+Requesters and approvers act as their 'tcld login' identity: the CLI checks their access token with Temporal
+Cloud and sends only the verified subject and account. API keys are not accepted. Connect to the same
+Temporal server as the waiting operation.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newApprovalsListCommand())
+	cmd.AddCommand(newApprovalDecisionCommand(workflows.ApproveUpdate, "Approve a pending operation"))
+	cmd.AddCommand(newApprovalDecisionCommand(workflows.RejectUpdate, "Reject a pending operation"))
+
+	return cmd
+}
+
+// newApprovalsListCommand creates the approvals list subcommand
+func newApprovalsListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List operations waiting for approval",
+		RunE:  runApprovalsList,
+	}
+}
+
+// newApprovalDecisionCommand creates the approvals approve and reject subcommands, which send the update of the same name
+func newApprovalDecisionCommand(update string, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   update + " <workflow-id>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApprovalDecision(cmd, args[0], update)
+		},
+	}
+
+	cmd.Flags().StringVar(&approver, "approver", "", "No longer accepted, the approver is the verified tcld login identity")
+	cmd.Flags().StringVarP(&approvalComment, "comment", "m", "", "Reason for the decision")
+	cmd.Flags().MarkHidden("approver")
+
+	return cmd
+}
+
+// runApprovalsList prints every running operation whose approval is pending
+func runApprovalsList(cmd *cobra.Command, args []string) error {
+	temporalService, err := NewTemporalService()
+	if err != nil {
+		return fmt.Errorf("failed to create TemporalService: %w", err)
+	}
+	defer temporalService.Stop()
+
+	ctx := cmd.Context()
+	pending := 0
+	var pageToken []byte
+	for {
+		resp, err := temporalService.GetClient().ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         "ExecutionStatus = 'Running'",
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list workflows: %w", err)
+		}
+		for _, execution := range resp.Executions {
+			payload, ok := execution.GetMemo().GetFields()[workflows.ApprovalMemoKey]
+			if !ok {
+				continue
+			}
+			var state workflows.ApprovalState
+			if err := converter.GetDefaultDataConverter().FromPayload(payload, &state); err != nil || state.Status != workflows.ApprovalPending {
+				continue
+			}
+			pending++
+			var approvers []string
+			for _, approval := range state.Approvals {
+				approvers = append(approvers, approval.Approver)
+			}
+			fmt.Printf("⏳ %s\n", execution.GetExecution().GetWorkflowId())
+			fmt.Printf("   %s\n", state.Operation)
+			fmt.Printf("   Requested by %s, %d/%d approvals %s, rejected at %s\n", state.RequestedBy, len(state.Approvals), state.Required,
+				strings.Join(approvers, ", "), state.Deadline.Local().Format(time.RFC3339))
+		}
+		if pageToken = resp.NextPageToken; len(pageToken) == 0 {
+			break
+		}
+	}
+	if pending == 0 {
+		fmt.Printf("No operations are waiting for approval\n")
+	}
+	return nil
+}
+
+// runApprovalDecision sends an approve or reject update to the waiting workflow
+func runApprovalDecision(cmd *cobra.Command, workflowID string, update string) error {
+	if cmd.Flags().Changed("approver") {
+		return fmt.Errorf("--approver is no longer accepted, decisions are made as the identity of 'tcld login'")
+	}
+	ctx := cmd.Context()
+	decider, accountID, err := verifiedActor(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to verify your identity: %w", err)
+	}
+	decision := &workflows.ApprovalDecision{
+		Approver:  decider,
+		AccountID: accountID,
+		Comment:   approvalComment,
+	}
+
+	temporalService, err := NewTemporalService()
+	if err != nil {
+		return fmt.Errorf("failed to create TemporalService: %w", err)
+	}
+	defer temporalService.Stop()
+
+	handle, err := temporalService.GetClient().UpdateWorkflow(ctx, client.UpdateWorkflowOptions{
+		WorkflowID:   workflowID,
+		UpdateName:   update,
+		Args:         []interface{}{decision},
+		WaitForStage: client.WorkflowUpdateStageCompleted,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to %s %s: %w", update, workflowID, err)
+	}
+	var state workflows.ApprovalState
+	if err := handle.Get(ctx, &state); err != nil {
+		return fmt.Errorf("failed to %s %s: %w", update, workflowID, err)
+	}
+
+	switch state.Status {
+	case workflows.ApprovalApproved:
+		fmt.Printf("✅ %s approved by %s, the operation continues\n", state.Operation, decider)
+	case workflows.ApprovalRejected:
+		fmt.Printf("🚫 %s rejected by %s\n", state.Operation, decider)
+	default:
+		fmt.Printf("👍 Approved by %s, %d/%d approvals\n", decider, len(state.Approvals), state.Required)
+	}
+	return nil
+}
+
+// verifiedActor returns the 'tcld login' subject of the user and their Cloud account, once Cloud accepts their token.
+// Only the verified subject is sent to workflows; the token never leaves the CLI.
+func verifiedActor(ctx context.Context) (string, string, error) {
+	auth, err := NewTcldAuth()
+	if err != nil {
+		return "", "", err
+	}
+	token, err := auth.GetToken()
+	if err != nil {
+		return "", "", err
+	}
+	return policy.VerifyLoginToken(ctx, token)
+}
+
+// approvalRequest returns the verified requester of a privileged operation and whether the policy file marks the
+// Cloud account as production. --no-policy does not skip it: the workflow fails rather than run unapproved when
+// the worker's policy has no approval rules for an account this policy file marks as production.
+func approvalRequest(ctx context.Context, cloudService cloudservicev1.CloudServiceClient) (workflows.ApprovalRequest, error) {
+	p, err := loadPolicy()
+	if err != nil {
+		return workflows.ApprovalRequest{}, err
+	}
+	approval, err := approvalFor(ctx, cloudService, p)
+	if err != nil {
+		return workflows.ApprovalRequest{}, err
+	}
+	requestedBy, _, err := verifiedActor(ctx)
+	if err != nil {
+		if approval != nil {
+			return workflows.ApprovalRequest{}, fmt.Errorf("approval is required, and approvers need to know who requested it: %w", err)
+		}
+		requestedBy = ""
+	}
+	return workflows.ApprovalRequest{RequestedBy: requestedBy, RequireApproval: approval != nil}, nil
+}
+
+// approvalFor announces the approval privileged operations need on the Cloud account. It only informs the user,
+// the operations worker resolves the approval from its own policy.
+func approvalFor(ctx context.Context, cloudService cloudservicev1.CloudServiceClient, p *policy.Policy) (*policy.Approval, error) {
+	if p == nil || len(p.Approvals.Accounts) == 0 {
+		return nil, nil
+	}
+	resp, err := cloudService.GetAccount(ctx, &cloudservicev1.GetAccountRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	accountID := resp.GetAccount().GetId()
+	approval := p.ApprovalFor(accountID)
+	if approval != nil {
+		fmt.Printf("🔐 Cloud account %s is production: privileged operations wait for %d approval(s)\n", accountID, max(approval.Approvers, 1))
+	}
+	return approval, nil
+}
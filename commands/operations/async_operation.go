@@ -0,0 +1,43 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"temporal-jumpstart-operations/workflows/activities"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+)
+
+// asyncOperationPollInterval is how often the CLI checks on a Cloud async operation
+const asyncOperationPollInterval = 2 * time.Second
+
+// waitForAsyncOperation blocks until the Cloud async operation is fulfilled, fails or the timeout passes.
+// It is the CLI counterpart of the workflows polling CheckOperationCompletion.
+func waitForAsyncOperation(ctx context.Context, cloudService cloudservicev1.CloudServiceClient, asyncOperationId string, timeout time.Duration) error {
+	if asyncOperationId == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	acts := newActivities(cloudService)
+	for {
+		_, err := acts.CheckOperationCompletion(ctx, &activities.CheckOperationCompletionRequest{
+			AsyncOperationId: asyncOperationId,
+		})
+		if err == nil {
+			return nil
+		}
+		if !strings.HasPrefix(err.Error(), activities.ERR_OPERATION_NOT_READY) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for operation %s: %w", asyncOperationId, ctx.Err())
+		case <-time.After(asyncOperationPollInterval):
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"temporal-jumpstart-operations/audit"
+	"temporal-jumpstart-operations/temporal"
+	"temporal-jumpstart-operations/workers"
+	"temporal-jumpstart-operations/workflows"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	// Audit sink flag shared by every command that calls the Cloud API
+	auditSink string
+
+	// Audit read flags
+	auditLines  int
+	auditFollow bool
+	auditJSON   bool
+	auditFilter audit.Filter
+	auditSince  string
+	auditUntil  string
+
+	// Cloud audit log export flags
+	exportSink     string
+	exportEvery    string
+	exportLookback string
+	exportPageSize int32
+	exportDelete   bool
+)
+
+// exportCloudAuditLogsWorkflowID prefixes the IDs of the export workflow and schedule of a sink
+const exportCloudAuditLogsWorkflowID = "export-cloud-audit-logs"
+
+// AddAuditFlags adds the flag selecting where identity mutations are audited
+func AddAuditFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&auditSink, "audit-sink", "", "Where every Cloud API mutation is recorded: a JSONL file or an http(s) URL records are POSTed to (defaults to <state-dir>/"+audit.DefaultLogFile+")")
+}
+
+// auditSinkURI returns the configured audit sink, the JSONL log in the state directory by default
+func auditSinkURI() string {
+	if auditSink != "" {
+		return auditSink
+	}
+	dir := stateDir
+	if dir == "" {
+		dir = temporal.DefaultStateDir()
+	}
+	return filepath.Join(dir, audit.DefaultLogFile)
+}
+
+// auditLogFile returns the local JSONL audit log the read commands use
+func auditLogFile() (string, error) {
+	sink, err := audit.ParseSink(auditSinkURI())
+	if err != nil {
+		return "", err
+	}
+	jsonl, ok := sink.(*audit.JSONLSink)
+	if !ok {
+		return "", fmt.Errorf("audit records sent to %s can only be read there", auditSinkURI())
+	}
+	return jsonl.Path, nil
+}
+
+// NewAuditCommand creates and returns the audit command with its subcommands
+func NewAuditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Read the audit log of identity mutations",
+		Long: `Read the audit log of every Cloud API mutation made by this tool: who made it, from which workflow,
+with which (redacted) spec, and the IDs it returned.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newAuditTailCommand())
+	cmd.AddCommand(newAuditSearchCommand())
+	cmd.AddCommand(newAuditExportCloudCommand())
+
+	return cmd
+}
+
+// newAuditTailCommand creates the audit tail subcommand
+func newAuditTailCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Show the latest audit records",
+		RunE:  runAuditTail,
+	}
+
+	cmd.Flags().IntVarP(&auditLines, "lines", "n", 20, "Number of records to show")
+	cmd.Flags().BoolVarP(&auditFollow, "follow", "f", false, "Keep printing new records until interrupted")
+	cmd.Flags().BoolVar(&auditJSON, "json", false, "Print records as JSON lines")
+
+	return cmd
+}
+
+// newAuditSearchCommand creates the audit search subcommand
+func newAuditSearchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search [text]",
+		Short: "Search audit records",
+		Long: `Search audit records. The optional text matches records whose spec or result contains it,
+e.g. a service account name or an API key ID.`,
+		Example: `  temporal-jumpstart-operations operations audit search --action CreateApiKey --since 720h
+  temporal-jumpstart-operations operations audit search billing-worker --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runAuditSearch,
+	}
+
+	cmd.Flags().StringVar(&auditFilter.Actor, "actor", "", "Token subject that made the call")
+	cmd.Flags().StringVar(&auditFilter.Action, "action", "", "Cloud API method, e.g. CreateServiceAccount")
+	cmd.Flags().StringVar(&auditFilter.WorkflowID, "workflow-id", "", "Workflow the call was made from")
+	cmd.Flags().StringVar(&auditSince, "since", "", "Only records started after this RFC 3339 time or this long ago, e.g. 24h")
+	cmd.Flags().StringVar(&auditUntil, "until", "", "Only records started before this RFC 3339 time or this long ago")
+	cmd.Flags().BoolVar(&auditFilter.FailedOnly, "failed", false, "Only failed calls")
+	cmd.Flags().BoolVar(&auditJSON, "json", false, "Print records as JSON lines")
+
+	return cmd
+}
+
+// newAuditExportCloudCommand creates the audit export-cloud subcommand
+func newAuditExportCloudCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-cloud",
+		Short: "Export the Temporal Cloud account audit log to a file, HTTP endpoint or S3",
+		Long: `Run the ExportCloudAuditLogs workflow, which pages through the Temporal Cloud account audit log and
+ships the records to a sink:
+
+  ./cloud-audit.jsonl                        append JSON lines to a local file
+  https://siem.example.com/ingest?token-env=SIEM_TOKEN   POST each page as JSON lines
+  s3://bucket/prefix?endpoint=https://minio:9000&region=eu-west-1   one object per page
+
+Without --every the export runs once from --lookback up to now. With --every a Temporal Schedule runs the
+export at that interval, each run resuming from the cursor of the last one. The schedule lives on the persistent
+local server or the existing cluster and its runs go to the shared 'worker run' task queue, --task-queue or
+"` + workers.DefaultTaskQueue + `", so a local file sink is written on that worker's host. Use --delete to remove it.
+Records are delivered at least once; deduplicate on logId.`,
+		RunE: runAuditExportCloud,
+	}
+
+	cmd.Flags().StringVar(&exportSink, "sink", "", "Export sink: a JSONL file, an http(s) URL or s3://bucket/prefix (required)")
+	cmd.Flags().StringVar(&exportEvery, "every", "", "Schedule the export at this interval, e.g. 15m (exports once when empty)")
+	cmd.Flags().StringVar(&exportLookback, "lookback", "1d", "How far back the first export starts, at most 30d")
+	cmd.Flags().Int32Var(&exportPageSize, "page-size", 100, "Records requested per page, at most 1000")
+	cmd.Flags().BoolVar(&exportDelete, "delete", false, "Delete the export schedule of the sink")
+	cmd.MarkFlagRequired("sink")
+
+	return cmd
+}
+
+// runAuditTail prints the last records and optionally follows the log
+func runAuditTail(cmd *cobra.Command, args []string) error {
+	path, err := auditLogFile()
+	if err != nil {
+		return err
+	}
+	records, err := audit.ReadRecords(path, audit.Filter{})
+	if err != nil {
+		return err
+	}
+	printed := len(records)
+	if len(records) > auditLines {
+		records = records[len(records)-auditLines:]
+	}
+	printAuditRecords(records)
+
+	for auditFollow {
+		select {
+		case <-cmd.Context().Done():
+			return nil
+		case <-time.After(time.Second):
+		}
+		records, err := audit.ReadRecords(path, audit.Filter{})
+		if err != nil {
+			return err
+		}
+		if len(records) > printed {
+			printAuditRecords(records[printed:])
+			printed = len(records)
+		}
+	}
+	return nil
+}
+
+// runAuditSearch prints the records matching the filters
+func runAuditSearch(cmd *cobra.Command, args []string) error {
+	path, err := auditLogFile()
+	if err != nil {
+		return err
+	}
+	filter := auditFilter
+	if len(args) == 1 {
+		filter.Text = args[0]
+	}
+	if filter.Since, err = parseAuditTime(auditSince); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if filter.Until, err = parseAuditTime(auditUntil); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	records, err := audit.ReadRecords(path, filter)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 && !auditJSON {
+		fmt.Fprintf(os.Stderr, "No audit records found in %s\n", path)
+		return nil
+	}
+	printAuditRecords(records)
+	return nil
+}
+
+// runAuditExportCloud exports the Cloud audit log once, or schedules the export
+func runAuditExportCloud(cmd *cobra.Command, args []string) error {
+	// Fail fast on bad flags rather than inside the workflow
+	sink, err := resolveExportSink(exportSink)
+	if err != nil {
+		return err
+	}
+	// the workflow and schedule are keyed on the sink, since --lookback is relative to now
+	workflowID, err := requestWorkflowID(exportCloudAuditLogsWorkflowID, sink)
+	if err != nil {
+		return err
+	}
+	if exportDelete {
+		return runAuditExportCloudUnschedule(cmd, workflowID)
+	}
+	if _, err := activities.ParseDuration(exportLookback); err != nil {
+		return fmt.Errorf("invalid lookback: %w", err)
+	}
+	var every time.Duration
+	if exportEvery != "" {
+		if every, err = activities.ParseDuration(exportEvery); err != nil || every <= 0 {
+			return fmt.Errorf("invalid interval %q", exportEvery)
+		}
+	}
+	request := &workflows.ExportCloudAuditLogsRequest{
+		Sink:     sink,
+		Lookback: exportLookback,
+		PageSize: exportPageSize,
+	}
+
+	ctx := cmd.Context()
+	if exportEvery == "" {
+		fmt.Printf("🔗 Connecting to Temporal Cloud...\n")
+		cloudService, closer, err := NewCloudServiceClient()
+		if err != nil {
+			return fmt.Errorf("failed to create cloud client: %w", err)
+		}
+		defer closer.Close()
+
+		var result workflows.ExportCloudAuditLogsResult
+		if err := runOperationsWorkflow(ctx, cloudService, workflowID, workflows.ExportCloudAuditLogs, request, &result); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Exported %d audit log records to %s (up to %s)\n", result.Exported, sink, result.Cursor.After.Local().Format(time.RFC3339))
+		return nil
+	}
+
+	taskQueue, err := scheduleTaskQueue()
+	if err != nil {
+		return err
+	}
+	temporalService, err := NewDurableTemporalService("export-cloud --every")
+	if err != nil {
+		return err
+	}
+	defer temporalService.Stop()
+
+	handle, created, err := upsertSchedule(ctx, temporalService.GetClient(), client.ScheduleOptions{
+		ID: operationsWorkflowID(workflowID),
+		Spec: client.ScheduleSpec{
+			Intervals: []client.ScheduleIntervalSpec{{Every: every}},
+		},
+		Action: &client.ScheduleWorkflowAction{
+			ID:        operationsWorkflowID(workflowID),
+			Workflow:  workflows.ExportCloudAuditLogs,
+			Args:      []interface{}{request},
+			TaskQueue: taskQueue,
+		},
+		TriggerImmediately: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule audit log export: %w", err)
+	}
+	if created {
+		fmt.Printf("📤 Scheduled the Cloud audit log export to %s every %s (schedule %s)\n", sink, every, handle.GetID())
+	} else {
+		fmt.Printf("📤 Updated the Cloud audit log export schedule %s to run every %s\n", handle.GetID(), every)
+	}
+	fmt.Printf("   Runs on the worker polling task queue %s; run 'export-cloud --sink %s --delete' to remove it\n", taskQueue, exportSink)
+	return nil
+}
+
+// resolveExportSink validates the sink and makes a local file sink absolute, since the workflow
+// may run on a worker with a different working directory
+func resolveExportSink(uri string) (string, error) {
+	sink, err := audit.ParseExportSink(uri)
+	if err != nil {
+		return "", err
+	}
+	file, ok := sink.(*audit.JSONLExportSink)
+	if !ok {
+		return uri, nil
+	}
+	return filepath.Abs(file.Path)
+}
+
+// runAuditExportCloudUnschedule deletes the export schedule of the sink
+func runAuditExportCloudUnschedule(cmd *cobra.Command, workflowID string) error {
+	temporalService, err := NewDurableTemporalService("export-cloud --delete")
+	if err != nil {
+		return err
+	}
+	defer temporalService.Stop()
+
+	id := operationsWorkflowID(workflowID)
+	deleted, err := deleteSchedule(cmd.Context(), temporalService.GetClient(), id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		fmt.Printf("ℹ️  No audit log export schedule %s for %s\n", id, exportSink)
+		return nil
+	}
+	fmt.Printf("🗑️  Deleted audit log export schedule %s\n", id)
+	return nil
+}
+
+// parseAuditTime accepts an RFC 3339 time or a duration before now
+func parseAuditTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// printAuditRecords prints one line per record, as JSON with --json
+func printAuditRecords(records []audit.Record) {
+	for _, record := range records {
+		if auditJSON {
+			line, _ := json.Marshal(record)
+			fmt.Println(string(line))
+			continue
+		}
+		status := "✅"
+		if record.Error != "" {
+			status = "❌"
+		}
+		source := record.WorkflowID
+		if source == "" {
+			source = "cli"
+		}
+		var ids []string
+		for key, id := range record.Result {
+			ids = append(ids, key+"="+id)
+		}
+		sort.Strings(ids)
+		fmt.Printf("%s %s  %-28s %-24s %-40s %s\n", status, record.StartedAt.Local().Format(time.RFC3339),
+			record.Action, record.Actor, source, strings.Join(ids, " "))
+		if record.Error != "" {
+			fmt.Printf("    error: %s\n", record.Error)
+		}
+	}
+}
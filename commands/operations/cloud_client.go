@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"io"
 
+	"temporal-jumpstart-operations/audit"
+	"temporal-jumpstart-operations/dryrun"
+
 	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
 	"go.temporal.io/cloud-sdk/cloudclient"
+	"google.golang.org/grpc"
 )
 
 // NewCloudServiceClient creates a CloudService client using tcld credentials
@@ -21,9 +25,24 @@ func NewCloudServiceClient() (cloudservicev1.CloudServiceClient, io.Closer, erro
 		return nil, nil, fmt.Errorf("no tcld credentials found. Please run 'tcld login' first: %w", err)
 	}
 
-	// Create client using the official Cloud SDK
+	// Every mutation is recorded to the audit sink
+	sink, err := audit.ParseSink(auditSinkURI())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid audit sink: %w", err)
+	}
+	dialOptions := cloudDialOptions()
+
+	// A dry run skips mutations before they are audited, so the audit log only ever holds real calls
+	if dryRun {
+		fmt.Printf("🧪 Dry run: mutating Cloud API calls are printed, not made\n")
+		dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(dryrun.UnaryClientInterceptor()))
+	}
+	dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(audit.UnaryClientInterceptor(sink, audit.ActorFromToken(token))))
+
+	// Create client using the official Cloud SDK, instrumented when telemetry is on
 	client, err := cloudclient.New(cloudclient.Options{
-		APIKey: token,
+		APIKey:          token,
+		GRPCDialOptions: dialOptions,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create cloud client: %w", err)
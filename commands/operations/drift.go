@@ -0,0 +1,205 @@
+package operations
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"temporal-jumpstart-operations/desiredstate"
+	"temporal-jumpstart-operations/workers"
+	"temporal-jumpstart-operations/workflows"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"github.com/spf13/cobra"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	// Drift command flags
+	driftDesiredStateFile string
+	driftReportPath       string
+	driftMaxKeyLifetime   string
+	driftInterval         string
+	driftDeleteSchedule   bool
+)
+
+// driftScheduleID is the ID of the drift detection schedule
+const driftScheduleID = "detect-identity-drift"
+
+// NewDriftCommand creates and returns the drift command with its subcommands
+func NewDriftCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Detect drift between Temporal Cloud identities and a desired state file",
+		Long: `Compare service accounts, API keys and namespace access in Temporal Cloud against a desired state file.
+Reports unmanaged accounts and keys, missing accounts and keys, role escalations and keys past the lifetime policy.`,
+	}
+
+	cmd.PersistentFlags().StringVarP(&driftDesiredStateFile, "file", "f", "", "Desired state file (required unless deleting the schedule)")
+	cmd.PersistentFlags().StringVarP(&driftReportPath, "report-path", "o", ".", "Directory drift reports are written to")
+	cmd.PersistentFlags().StringVar(&driftMaxKeyLifetime, "max-key-lifetime", "1y", "Longest lifetime an API key may have")
+
+	cmd.AddCommand(newDriftDetectCommand())
+	cmd.AddCommand(newDriftScheduleCommand())
+
+	return cmd
+}
+
+// newDriftDetectCommand creates the drift detect subcommand
+func newDriftDetectCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "detect",
+		Short: "Run drift detection once",
+		Long:  `Run the DetectIdentityDrift workflow once and write a drift report.`,
+		RunE:  runDriftDetect,
+	}
+}
+
+// newDriftScheduleCommand creates the drift schedule subcommand
+func newDriftScheduleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Run drift detection periodically",
+		Long: `Create or update the Temporal Schedule that runs the DetectIdentityDrift workflow every interval.
+The schedule lives on the persistent local server or the existing cluster, so it survives the command.
+Its runs go to the shared 'worker run' task queue, --task-queue or "` + workers.DefaultTaskQueue + `",
+and read the desired state file and write reports on that worker's host.
+Use --delete to remove it.`,
+		RunE: runDriftSchedule,
+	}
+
+	cmd.Flags().StringVar(&driftInterval, "every", "24h", "Interval between drift detection runs")
+	cmd.Flags().BoolVar(&driftDeleteSchedule, "delete", false, "Delete the drift detection schedule")
+
+	return cmd
+}
+
+// driftRequest builds the workflow request from the drift flags after validating them
+func driftRequest() (*workflows.DetectIdentityDriftRequest, error) {
+	if driftDesiredStateFile == "" {
+		return nil, fmt.Errorf("--file is required")
+	}
+	// Fail fast on a bad desired state file rather than inside the workflow
+	if _, err := desiredstate.Load(driftDesiredStateFile); err != nil {
+		return nil, err
+	}
+	if _, err := activities.ParseDuration(driftMaxKeyLifetime); err != nil {
+		return nil, fmt.Errorf("invalid max-key-lifetime: %w", err)
+	}
+	// the paths are resolved here, since the worker running the workflow has a different working directory
+	desiredStatePath, err := filepath.Abs(driftDesiredStateFile)
+	if err != nil {
+		return nil, err
+	}
+	reportPath, err := filepath.Abs(driftReportPath)
+	if err != nil {
+		return nil, err
+	}
+	return &workflows.DetectIdentityDriftRequest{
+		DesiredStatePath: desiredStatePath,
+		ReportPath:       reportPath,
+		MaxKeyLifetime:   driftMaxKeyLifetime,
+	}, nil
+}
+
+// runDriftDetect runs a single drift detection and prints the summary
+func runDriftDetect(cmd *cobra.Command, args []string) error {
+	request, err := driftRequest()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔗 Connecting to Temporal Cloud...\n")
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	workflowID, err := requestWorkflowID("detect-identity-drift", request)
+	if err != nil {
+		return err
+	}
+	var result desiredstate.DetectDriftResponse
+	if err := runOperationsWorkflow(cmd.Context(), cloudService, workflowID, workflows.DetectIdentityDrift, request, &result); err != nil {
+		return err
+	}
+
+	if result.Findings > 0 {
+		fmt.Printf("\n⚠️  Drift detected: %s\n", result.Summary)
+	} else {
+		fmt.Printf("\n✅ No drift detected\n")
+	}
+	fmt.Printf("   Report: %s\n", result.ReportFile)
+	return nil
+}
+
+// runDriftSchedule creates or updates the drift detection schedule
+func runDriftSchedule(cmd *cobra.Command, args []string) error {
+	if driftDeleteSchedule {
+		return runDriftUnschedule(cmd)
+	}
+	request, err := driftRequest()
+	if err != nil {
+		return err
+	}
+	every, err := activities.ParseDuration(driftInterval)
+	if err != nil {
+		return fmt.Errorf("invalid interval: %w", err)
+	}
+	taskQueue, err := scheduleTaskQueue()
+	if err != nil {
+		return err
+	}
+
+	temporalService, err := NewDurableTemporalService("drift schedule")
+	if err != nil {
+		return err
+	}
+	defer temporalService.Stop()
+
+	handle, created, err := upsertSchedule(cmd.Context(), temporalService.GetClient(), client.ScheduleOptions{
+		ID: operationsWorkflowID(driftScheduleID),
+		Spec: client.ScheduleSpec{
+			Intervals: []client.ScheduleIntervalSpec{{Every: every}},
+		},
+		Action: &client.ScheduleWorkflowAction{
+			ID:        operationsWorkflowID(driftScheduleID),
+			Workflow:  workflows.DetectIdentityDrift,
+			Args:      []interface{}{request},
+			TaskQueue: taskQueue,
+		},
+		TriggerImmediately: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule drift detection: %w", err)
+	}
+	if created {
+		fmt.Printf("📅 Scheduled drift detection every %s (schedule %s)\n", every, handle.GetID())
+	} else {
+		fmt.Printf("📅 Updated drift detection schedule %s to run every %s\n", handle.GetID(), every)
+	}
+	fmt.Printf("   Reports: %s\n", request.ReportPath)
+	fmt.Printf("   Runs on the worker polling task queue %s; run 'drift schedule --delete' to remove it\n", taskQueue)
+	return nil
+}
+
+// runDriftUnschedule deletes the drift detection schedule
+func runDriftUnschedule(cmd *cobra.Command) error {
+	temporalService, err := NewDurableTemporalService("drift schedule --delete")
+	if err != nil {
+		return err
+	}
+	defer temporalService.Stop()
+
+	id := operationsWorkflowID(driftScheduleID)
+	deleted, err := deleteSchedule(cmd.Context(), temporalService.GetClient(), id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		fmt.Printf("ℹ️  No drift detection schedule %s\n", id)
+		return nil
+	}
+	fmt.Printf("🗑️  Deleted drift detection schedule %s\n", id)
+	return nil
+}
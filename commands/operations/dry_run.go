@@ -0,0 +1,49 @@
+package operations
+
+import (
+	"temporal-jumpstart-operations/dryrun"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"github.com/spf13/pflag"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+)
+
+// dryRunTaskQueueSuffix keeps dry run workflows away from workers that would really run them
+const dryRunTaskQueueSuffix = "-dry-run"
+
+var (
+	// Dry run flag shared by every command that calls the Cloud API
+	dryRun bool
+)
+
+// AddDryRunFlags adds the flag previewing the Cloud calls a command would make
+func AddDryRunFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&dryRun, "dry-run", false, "Run validation, uniqueness lookups and policy checks, then print the Cloud calls that would be made instead of making them")
+}
+
+// operationsWorkerTaskQueue returns the task queue the operations workflows run on: the shared worker's
+// given by --task-queue, otherwise the one the in-process operations worker polls
+func operationsWorkerTaskQueue() string {
+	if workerTaskQueue != "" {
+		return workerTaskQueue
+	}
+	if dryRun {
+		return operationsTaskQueue() + dryRunTaskQueueSuffix
+	}
+	return operationsTaskQueue()
+}
+
+// operationsWorkflowID marks dry run workflow IDs so they never reuse a real workflow or schedule
+func operationsWorkflowID(id string) string {
+	if dryRun {
+		return dryrun.IDPrefix + id
+	}
+	return id
+}
+
+// newActivities returns the activities the direct CLI paths call, describing local side effects on a dry run
+func newActivities(cloudService cloudservicev1.CloudServiceClient) *activities.Activities {
+	acts := activities.NewActivities(cloudService)
+	acts.DryRun = dryRun
+	return acts
+}
@@ -0,0 +1,247 @@
+package operations
+
+import (
+	"fmt"
+
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Group command flags
+	groupName            string
+	groupMemberEmail     string
+	groupAccountRole     string
+	groupNamespaceAccess []string
+)
+
+// NewGroupCommand creates and returns the group command with its subcommands
+func NewGroupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "group",
+		Short: "Manage Temporal Cloud user groups",
+		Long:  `Create and delete Temporal Cloud user groups and manage their members.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newGroupCreateCommand())
+	cmd.AddCommand(newGroupListCommand())
+	cmd.AddCommand(newGroupDeleteCommand())
+	cmd.AddCommand(newGroupMembersCommand())
+	cmd.AddCommand(newGroupMemberCommand("add-member", "Add a user to a group", runGroupAddMember))
+	cmd.AddCommand(newGroupMemberCommand("remove-member", "Remove a user from a group", runGroupRemoveMember))
+
+	return cmd
+}
+
+// newGroupCreateCommand creates the group create subcommand
+func newGroupCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a user group",
+		RunE:  runGroupCreate,
+	}
+
+	cmd.Flags().StringVarP(&groupName, "name", "n", "", "Group name (required)")
+	addAccessFlags(cmd, &groupAccountRole, &groupNamespaceAccess)
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// newGroupListCommand creates the group list subcommand
+func newGroupListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List user groups",
+		RunE:  runGroupList,
+	}
+}
+
+// newGroupDeleteCommand creates the group delete subcommand
+func newGroupDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a user group",
+		RunE:  runGroupDelete,
+	}
+
+	cmd.Flags().StringVarP(&groupName, "name", "n", "", "Group name (required)")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// newGroupMembersCommand creates the group members subcommand
+func newGroupMembersCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "members",
+		Short: "List the members of a user group",
+		RunE:  runGroupMembers,
+	}
+
+	cmd.Flags().StringVarP(&groupName, "name", "n", "", "Group name (required)")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// newGroupMemberCommand creates a subcommand that changes the membership of a single user
+func newGroupMemberCommand(use string, short string, run func(*cobra.Command, []string) error) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		RunE:  run,
+	}
+
+	cmd.Flags().StringVarP(&groupName, "name", "n", "", "Group name (required)")
+	cmd.Flags().StringVarP(&groupMemberEmail, "email", "e", "", "User email (required)")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("email")
+
+	return cmd
+}
+
+// runGroupCreate creates a user group and waits for it
+func runGroupCreate(cmd *cobra.Command, args []string) error {
+	access, err := accessFromFlags(groupAccountRole, groupNamespaceAccess)
+	if err != nil {
+		return err
+	}
+
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	fmt.Printf("👥 Creating group '%s' (%s)...\n", groupName, access)
+	resp, err := newActivities(cloudService).CreateUserGroup(ctx, &activities.CreateUserGroupRequest{
+		DisplayName: groupName,
+		Access:      access,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create group: %w", err)
+	}
+	if err := waitForAsyncOperation(ctx, cloudService, resp.AsyncOperationId, userOperationTimeout); err != nil {
+		return fmt.Errorf("failed to create group: %w", err)
+	}
+
+	fmt.Printf("✅ Created group: %s (ID: %s)\n", groupName, resp.GroupId)
+	return nil
+}
+
+// runGroupList prints every user group in the account
+func runGroupList(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	groups, err := activities.ListUserGroups(cmd.Context(), cloudService)
+	if err != nil {
+		return fmt.Errorf("failed to list groups: %w", err)
+	}
+	for _, group := range groups {
+		fmt.Printf("%s\t%s\t%s\n", group.Id, group.GetSpec().GetDisplayName(),
+			activities.AccessFromIdentity(group.GetSpec().GetAccess()))
+	}
+	return nil
+}
+
+// runGroupDelete deletes a user group and waits for the deletion
+func runGroupDelete(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	fmt.Printf("🗑️  Deleting group '%s'...\n", groupName)
+	resp, err := newActivities(cloudService).DeleteUserGroup(ctx, &activities.DeleteUserGroupRequest{
+		DisplayName: groupName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+	if err := waitForAsyncOperation(ctx, cloudService, resp.AsyncOperationId, userOperationTimeout); err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	fmt.Printf("✅ Deleted group: %s\n", groupName)
+	return nil
+}
+
+// runGroupMembers prints the members of a user group
+func runGroupMembers(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	members, err := newActivities(cloudService).GetUserGroupMembers(cmd.Context(), &activities.GetUserGroupMembersRequest{
+		GroupName: groupName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list group members: %w", err)
+	}
+	for _, member := range members {
+		fmt.Println(member)
+	}
+	return nil
+}
+
+// runGroupAddMember adds a user to a group and waits for the change
+func runGroupAddMember(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	fmt.Printf("➕ Adding '%s' to group '%s'...\n", groupMemberEmail, groupName)
+	resp, err := newActivities(cloudService).AddUserGroupMember(ctx, &activities.UserGroupMemberRequest{
+		GroupName: groupName,
+		Email:     groupMemberEmail,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add group member: %w", err)
+	}
+	if err := waitForAsyncOperation(ctx, cloudService, resp.AsyncOperationId, userOperationTimeout); err != nil {
+		return fmt.Errorf("failed to add group member: %w", err)
+	}
+
+	fmt.Printf("✅ Added %s to group %s\n", groupMemberEmail, groupName)
+	return nil
+}
+
+// runGroupRemoveMember removes a user from a group and waits for the change
+func runGroupRemoveMember(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	fmt.Printf("➖ Removing '%s' from group '%s'...\n", groupMemberEmail, groupName)
+	resp, err := newActivities(cloudService).RemoveUserGroupMember(ctx, &activities.UserGroupMemberRequest{
+		GroupName: groupName,
+		Email:     groupMemberEmail,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove group member: %w", err)
+	}
+	if err := waitForAsyncOperation(ctx, cloudService, resp.AsyncOperationId, userOperationTimeout); err != nil {
+		return fmt.Errorf("failed to remove group member: %w", err)
+	}
+
+	fmt.Printf("✅ Removed %s from group %s\n", groupMemberEmail, groupName)
+	return nil
+}
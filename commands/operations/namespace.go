@@ -0,0 +1,347 @@
+package operations
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"temporal-jumpstart-operations/workflows"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Namespace command flags
+	namespaceName                    string
+	namespaceID                      string
+	namespaceRegions                 []string
+	namespaceRetentionDays           int32
+	namespaceAuthMethod              string
+	namespaceCACertsFile             string
+	namespaceSearchAttributes        []string
+	namespaceCodecEndpoint           string
+	namespaceCodecPassAccessToken    bool
+	namespaceCodecIncludeCredentials bool
+	namespaceDeleteProtection        bool
+)
+
+// namespaceOperationTimeout bounds how long update and delete wait for Cloud to finish
+const namespaceOperationTimeout = 30 * time.Minute
+
+// NewNamespaceCommand creates and returns the namespace command
+func NewNamespaceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "namespace",
+		Short: "Manage Temporal Cloud namespaces",
+		Long:  `Provision and manage namespaces in Temporal Cloud for jumpstart operations.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newNamespaceCreateCommand())
+	cmd.AddCommand(newNamespaceGetCommand())
+	cmd.AddCommand(newNamespaceListCommand())
+	cmd.AddCommand(newNamespaceUpdateCommand())
+	cmd.AddCommand(newNamespaceDeleteCommand())
+
+	return cmd
+}
+
+// addNamespaceSpecFlags defines the flags shared by namespace create and update
+func addNamespaceSpecFlags(cmd *cobra.Command) {
+	cmd.Flags().Int32Var(&namespaceRetentionDays, "retention-days", 30, "Workflow history retention in days")
+	cmd.Flags().StringVar(&namespaceAuthMethod, "auth-method", activities.AuthMethodApiKey, "Client auth method: api_key or mtls")
+	cmd.Flags().StringVar(&namespaceCACertsFile, "ca-certs", "", "PEM file with the CA certificates accepted for mtls auth")
+	cmd.Flags().StringArrayVar(&namespaceSearchAttributes, "search-attribute", nil, "Custom search attribute as {name}={type}, e.g. CustomerId=keyword (repeatable)")
+	cmd.Flags().StringVar(&namespaceCodecEndpoint, "codec-endpoint", "", "Codec server https endpoint used by the Cloud UI")
+	cmd.Flags().BoolVar(&namespaceCodecPassAccessToken, "codec-pass-access-token", false, "Pass the user access token to the codec server")
+	cmd.Flags().BoolVar(&namespaceCodecIncludeCredentials, "codec-include-credentials", false, "Include cross-origin credentials in codec server requests")
+}
+
+// newNamespaceCreateCommand creates the namespace create subcommand
+func newNamespaceCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a Temporal Cloud namespace",
+		Long:  `Create a namespace in Temporal Cloud through the CreateNamespace workflow and wait until it is ready.`,
+		RunE:  runNamespaceCreate,
+	}
+
+	cmd.Flags().StringVarP(&namespaceName, "name", "n", "", "Namespace name without the account suffix (required)")
+	cmd.Flags().StringSliceVar(&namespaceRegions, "region", nil, "Cloud region, e.g. aws-us-east-1 (required, repeatable)")
+	cmd.Flags().BoolVar(&namespaceDeleteProtection, "enable-delete-protection", false, "Prevent the namespace from being deleted")
+	addNamespaceSpecFlags(cmd)
+
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("region")
+
+	return cmd
+}
+
+// newNamespaceGetCommand creates the namespace get subcommand
+func newNamespaceGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show a Temporal Cloud namespace",
+		RunE:  runNamespaceGet,
+	}
+
+	cmd.Flags().StringVarP(&namespaceID, "namespace", "n", "", "Namespace id, e.g. my-ns.a1b2c (required)")
+	cmd.MarkFlagRequired("namespace")
+
+	return cmd
+}
+
+// newNamespaceListCommand creates the namespace list subcommand
+func newNamespaceListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List Temporal Cloud namespaces",
+		RunE:  runNamespaceList,
+	}
+}
+
+// newNamespaceUpdateCommand creates the namespace update subcommand
+func newNamespaceUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a Temporal Cloud namespace",
+		Long:  `Update the retention, auth method, search attributes or codec server of a namespace. Only flags that are set are changed.`,
+		RunE:  runNamespaceUpdate,
+	}
+
+	cmd.Flags().StringVarP(&namespaceID, "namespace", "n", "", "Namespace id, e.g. my-ns.a1b2c (required)")
+	addNamespaceSpecFlags(cmd)
+	cmd.MarkFlagRequired("namespace")
+
+	return cmd
+}
+
+// newNamespaceDeleteCommand creates the namespace delete subcommand
+func newNamespaceDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a Temporal Cloud namespace",
+		Long:  `Delete a namespace from Temporal Cloud. Namespaces with delete protection enabled are refused.`,
+		RunE:  runNamespaceDelete,
+	}
+
+	cmd.Flags().StringVarP(&namespaceID, "namespace", "n", "", "Namespace id, e.g. my-ns.a1b2c (required)")
+	cmd.MarkFlagRequired("namespace")
+
+	return cmd
+}
+
+// readCACerts reads the CA bundle file if one was given
+func readCACerts() (string, error) {
+	if namespaceCACertsFile == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(namespaceCACertsFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read ca certs: %w", err)
+	}
+	return string(data), nil
+}
+
+// parseSearchAttributes parses "{name}={type}" entries
+func parseSearchAttributes(entries []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, entry := range entries {
+		name, t, ok := strings.Cut(entry, "=")
+		if !ok || name == "" || t == "" {
+			return nil, fmt.Errorf("invalid search attribute %q, expected {name}={type}", entry)
+		}
+		if _, err := activities.ParseSearchAttributeType(t); err != nil {
+			return nil, fmt.Errorf("search attribute %s: %w", name, err)
+		}
+		result[name] = strings.ToLower(t)
+	}
+	return result, nil
+}
+
+// codecServerFromFlags returns the codec server settings if an endpoint was given
+func codecServerFromFlags() *activities.CodecServer {
+	if namespaceCodecEndpoint == "" {
+		return nil
+	}
+	return &activities.CodecServer{
+		Endpoint:                      namespaceCodecEndpoint,
+		PassAccessToken:               namespaceCodecPassAccessToken,
+		IncludeCrossOriginCredentials: namespaceCodecIncludeCredentials,
+	}
+}
+
+// runNamespaceCreate runs the CreateNamespace workflow
+func runNamespaceCreate(cmd *cobra.Command, args []string) error {
+	caCerts, err := readCACerts()
+	if err != nil {
+		return err
+	}
+	searchAttributes, err := parseSearchAttributes(namespaceSearchAttributes)
+	if err != nil {
+		return err
+	}
+	spec := &activities.NamespaceSpec{
+		Name:                   namespaceName,
+		Regions:                namespaceRegions,
+		RetentionDays:          namespaceRetentionDays,
+		AuthMethod:             namespaceAuthMethod,
+		AcceptedClientCA:       caCerts,
+		SearchAttributes:       searchAttributes,
+		CodecServer:            codecServerFromFlags(),
+		EnableDeleteProtection: namespaceDeleteProtection,
+	}
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	// Display the parsed arguments
+	fmt.Printf("Configuration:\n")
+	fmt.Printf("  Namespace Name: %s\n", spec.Name)
+	fmt.Printf("  Regions: %s\n", strings.Join(spec.Regions, ", "))
+	fmt.Printf("  Retention Days: %d\n", spec.RetentionDays)
+	fmt.Printf("  Auth Method: %s\n", spec.AuthMethod)
+
+	fmt.Printf("\n🔗 Connecting to Temporal Cloud...\n")
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	var info activities.NamespaceInfo
+	workflowID := fmt.Sprintf("create-namespace-%s", spec.Name)
+	if err := runOperationsWorkflow(cmd.Context(), cloudService, workflowID, workflows.CreateNamespace, &workflows.CreateNamespaceRequest{
+		Spec: spec,
+	}, &info); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n🎉 Namespace created successfully!\n")
+	printNamespace(&info)
+	return nil
+}
+
+// runNamespaceGet prints a single namespace
+func runNamespaceGet(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	info, err := newActivities(cloudService).GetNamespace(cmd.Context(), &activities.GetNamespaceRequest{
+		Namespace: namespaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get namespace: %w", err)
+	}
+	printNamespace(info)
+	return nil
+}
+
+// runNamespaceList prints every namespace in the account
+func runNamespaceList(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	namespaces, err := activities.ListNamespaces(cmd.Context(), cloudService)
+	if err != nil {
+		return fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	for _, ns := range namespaces {
+		info := activities.NamespaceInfoFromCloud(ns)
+		fmt.Printf("%s\t%s\t%s\t%s\n", info.Namespace, info.State, strings.Join(info.Regions, ","), info.GrpcAddress)
+	}
+	return nil
+}
+
+// runNamespaceUpdate applies the changed flags to the namespace and waits for the update
+func runNamespaceUpdate(cmd *cobra.Command, args []string) error {
+	request := &activities.UpdateNamespaceRequest{
+		Namespace: namespaceID,
+	}
+	if cmd.Flags().Changed("retention-days") {
+		request.RetentionDays = &namespaceRetentionDays
+	}
+	if cmd.Flags().Changed("auth-method") {
+		request.AuthMethod = namespaceAuthMethod
+	}
+	caCerts, err := readCACerts()
+	if err != nil {
+		return err
+	}
+	request.AcceptedClientCA = caCerts
+	if request.SearchAttributes, err = parseSearchAttributes(namespaceSearchAttributes); err != nil {
+		return err
+	}
+	request.CodecServer = codecServerFromFlags()
+
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	fmt.Printf("✏️  Updating namespace '%s'...\n", namespaceID)
+	resp, err := newActivities(cloudService).UpdateNamespace(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to update namespace: %w", err)
+	}
+	if err := waitForAsyncOperation(ctx, cloudService, resp.AsyncOperationId, namespaceOperationTimeout); err != nil {
+		return fmt.Errorf("failed to update namespace: %w", err)
+	}
+
+	fmt.Printf("✅ Updated namespace: %s\n", namespaceID)
+	return nil
+}
+
+// runNamespaceDelete deletes the namespace and waits for the deletion
+func runNamespaceDelete(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	fmt.Printf("🗑️  Deleting namespace '%s' from Temporal Cloud...\n", namespaceID)
+	resp, err := newActivities(cloudService).DeleteNamespace(ctx, &activities.DeleteNamespaceRequest{
+		Namespace: namespaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete namespace: %w", err)
+	}
+	if err := waitForAsyncOperation(ctx, cloudService, resp.AsyncOperationId, namespaceOperationTimeout); err != nil {
+		return fmt.Errorf("failed to delete namespace: %w", err)
+	}
+
+	fmt.Printf("✅ Deleted namespace: %s\n", namespaceID)
+	return nil
+}
+
+// printNamespace writes the namespace details to stdout
+func printNamespace(info *activities.NamespaceInfo) {
+	fmt.Printf("  Namespace: %s\n", info.Namespace)
+	fmt.Printf("  State: %s\n", info.State)
+	fmt.Printf("  Regions: %s\n", strings.Join(info.Regions, ", "))
+	fmt.Printf("  Retention Days: %d\n", info.RetentionDays)
+	fmt.Printf("  Auth Method: %s\n", info.AuthMethod)
+	fmt.Printf("  gRPC Address: %s\n", info.GrpcAddress)
+	if info.MtlsGrpcAddress != "" {
+		fmt.Printf("  mTLS gRPC Address: %s\n", info.MtlsGrpcAddress)
+	}
+	fmt.Printf("  Web Address: %s\n", info.WebAddress)
+	for _, name := range info.SortedSearchAttributes() {
+		fmt.Printf("  Search Attribute: %s (%s)\n", name, info.SearchAttributes[name])
+	}
+	if info.CodecServer != nil {
+		fmt.Printf("  Codec Server: %s\n", info.CodecServer.Endpoint)
+	}
+}
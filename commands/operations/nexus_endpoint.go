@@ -0,0 +1,221 @@
+package operations
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"temporal-jumpstart-operations/workflows"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"github.com/spf13/cobra"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+)
+
+var (
+	// Nexus endpoint command flags
+	nexusTarget                  string
+	nexusEndpointName            string
+	nexusEndpointDescription     string
+	nexusTargetNamespace         string
+	nexusTargetTaskQueue         string
+	nexusAllowedCallerNamespaces []string
+)
+
+// nexusOperationTimeout bounds how long delete waits for Cloud to finish
+const nexusOperationTimeout = 5 * time.Minute
+
+// NewNexusEndpointCommand creates and returns the nexus-endpoint command with its subcommands
+func NewNexusEndpointCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nexus-endpoint",
+		Short: "Manage Nexus endpoints",
+		Long: `Manage Nexus endpoints in Temporal Cloud (--target cloud) or on the local TemporalService (--target local).
+Local endpoints do not need tcld credentials. They live on the persistent local server ('server start')
+or on the cluster given by --temporal-address or --temporal-profile.`,
+	}
+
+	cmd.PersistentFlags().StringVar(&nexusTarget, "target", activities.NexusTargetCloud, "Where the endpoints live: cloud or local")
+
+	// Add subcommands
+	cmd.AddCommand(newNexusEndpointCreateCommand())
+	cmd.AddCommand(newNexusEndpointListCommand())
+	cmd.AddCommand(newNexusEndpointDeleteCommand())
+
+	return cmd
+}
+
+// newNexusEndpointCreateCommand creates the nexus-endpoint create subcommand
+func newNexusEndpointCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a Nexus endpoint",
+		Long:  `Create a Nexus endpoint routing to a namespace and task queue through the CreateNexusEndpoint workflow.`,
+		RunE:  runNexusEndpointCreate,
+	}
+
+	cmd.Flags().StringVarP(&nexusEndpointName, "name", "n", "", "Endpoint name (required)")
+	cmd.Flags().StringVar(&nexusEndpointDescription, "description", "", "Endpoint description")
+	cmd.Flags().StringVar(&nexusTargetNamespace, "target-namespace", "", "Namespace whose workers handle the operations (required)")
+	cmd.Flags().StringVar(&nexusTargetTaskQueue, "target-task-queue", "", "Task queue those workers poll (required)")
+	cmd.Flags().StringSliceVar(&nexusAllowedCallerNamespaces, "allowed-caller-namespace", nil, "Namespace allowed to call the endpoint, Cloud only (repeatable)")
+
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("target-namespace")
+	cmd.MarkFlagRequired("target-task-queue")
+
+	return cmd
+}
+
+// newNexusEndpointListCommand creates the nexus-endpoint list subcommand
+func newNexusEndpointListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List Nexus endpoints",
+		RunE:  runNexusEndpointList,
+	}
+}
+
+// newNexusEndpointDeleteCommand creates the nexus-endpoint delete subcommand
+func newNexusEndpointDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a Nexus endpoint",
+		RunE:  runNexusEndpointDelete,
+	}
+
+	cmd.Flags().StringVarP(&nexusEndpointName, "name", "n", "", "Endpoint name (required)")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// nexusCloudClient connects to Temporal Cloud unless the target is local, which needs no credentials
+func nexusCloudClient() (cloudservicev1.CloudServiceClient, io.Closer, error) {
+	if nexusTarget == activities.NexusTargetLocal {
+		return nil, io.NopCloser(nil), nil
+	}
+	if nexusTarget != activities.NexusTargetCloud {
+		return nil, nil, fmt.Errorf("unknown target %q, expected %s or %s", nexusTarget, activities.NexusTargetCloud, activities.NexusTargetLocal)
+	}
+	fmt.Printf("🔗 Connecting to Temporal Cloud...\n")
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	return cloudService, closer, nil
+}
+
+// runNexusEndpointCreate runs the CreateNexusEndpoint workflow
+func runNexusEndpointCreate(cmd *cobra.Command, args []string) error {
+	spec := &activities.NexusEndpointSpec{
+		Name:                    nexusEndpointName,
+		Description:             nexusEndpointDescription,
+		TargetNamespace:         nexusTargetNamespace,
+		TargetTaskQueue:         nexusTargetTaskQueue,
+		AllowedCallerNamespaces: nexusAllowedCallerNamespaces,
+	}
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	cloudService, closer, err := nexusCloudClient()
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	runtime, err := startNexusRuntime(cloudService)
+	if err != nil {
+		return err
+	}
+	defer runtime.Stop()
+
+	var result activities.NexusEndpointOperationResponse
+	workflowID := fmt.Sprintf("create-nexus-endpoint-%s", spec.Name)
+	if err := executeOperationsWorkflow(cmd.Context(), runtime, workflowID, workflows.CreateNexusEndpoint, &workflows.CreateNexusEndpointRequest{
+		Target: nexusTarget,
+		Spec:   spec,
+	}, &result); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n🎉 Nexus endpoint created successfully!\n")
+	fmt.Printf("   Endpoint: %s (ID: %s)\n", spec.Name, result.EndpointId)
+	fmt.Printf("   Target: %s/%s (%s)\n", spec.TargetNamespace, spec.TargetTaskQueue, nexusTarget)
+	return nil
+}
+
+// runNexusEndpointList prints every Nexus endpoint of the target
+func runNexusEndpointList(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := nexusCloudClient()
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	return withNexusEndpoints(cloudService, func(endpoints activities.NexusEndpoints) error {
+		list, err := endpoints.List(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to list nexus endpoints: %w", err)
+		}
+		for _, endpoint := range list {
+			fmt.Printf("%s\t%s\t%s/%s\t%s\n", endpoint.Id, endpoint.Name, endpoint.TargetNamespace, endpoint.TargetTaskQueue,
+				strings.Join(endpoint.AllowedCallerNamespaces, ","))
+		}
+		return nil
+	})
+}
+
+// runNexusEndpointDelete deletes a Nexus endpoint of the target
+func runNexusEndpointDelete(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := nexusCloudClient()
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	return withNexusEndpoints(cloudService, func(endpoints activities.NexusEndpoints) error {
+		fmt.Printf("🗑️  Deleting nexus endpoint '%s'...\n", nexusEndpointName)
+		resp, err := endpoints.Delete(ctx, nexusEndpointName, "")
+		if err != nil {
+			return fmt.Errorf("failed to delete nexus endpoint: %w", err)
+		}
+		if err := waitForAsyncOperation(ctx, cloudService, resp.AsyncOperationId, nexusOperationTimeout); err != nil {
+			return fmt.Errorf("failed to delete nexus endpoint: %w", err)
+		}
+		fmt.Printf("✅ Deleted nexus endpoint: %s\n", nexusEndpointName)
+		return nil
+	})
+}
+
+// startNexusRuntime starts the operations runtime for the target. Local endpoints live on the Temporal server itself,
+// so the local target needs the persistent server or an existing cluster; a throwaway dev server would lose them.
+func startNexusRuntime(cloudService cloudservicev1.CloudServiceClient) (*operationsRuntime, error) {
+	if nexusTarget == activities.NexusTargetLocal {
+		return startDurableOperationsRuntime(cloudService, "--target local")
+	}
+	return startOperationsRuntime(cloudService)
+}
+
+// withNexusEndpoints calls fn with the NexusEndpoints of the target, connecting to the
+// Temporal server first when the target is local
+func withNexusEndpoints(cloudService cloudservicev1.CloudServiceClient, fn func(activities.NexusEndpoints) error) error {
+	acts := newActivities(cloudService)
+	if nexusTarget == activities.NexusTargetLocal {
+		runtime, err := startNexusRuntime(cloudService)
+		if err != nil {
+			return err
+		}
+		defer runtime.Stop()
+		acts = runtime.Activities()
+	}
+
+	endpoints, err := acts.NexusEndpointsFor(nexusTarget)
+	if err != nil {
+		return err
+	}
+	return fn(endpoints)
+}
@@ -0,0 +1,151 @@
+package operations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"temporal-jumpstart-operations/notify"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	// Notification flags shared by every command that runs operations workflows
+	notifyURIs         []string
+	notifyTeamURIs     []string
+	notifyTemplateFile string
+
+	// Notify test flags
+	notifyTestFailed bool
+	notifyTestTeam   string
+)
+
+// AddNotifyFlags adds the flags selecting who is told when long-running operations finish or fail
+func AddNotifyFlags(flags *pflag.FlagSet) {
+	flags.StringArrayVar(&notifyURIs, "notify", nil, "Notify on operation outcomes: slack+https://..., teams+https://..., an http(s) URL for JSON, or smtp://host:port?from=...&to=... (repeatable)")
+	flags.StringArrayVar(&notifyTeamURIs, "notify-team", nil, "Also notify the owner team of a service account as {team}={notifier}, with the same notifiers as --notify (repeatable)")
+	flags.StringVar(&notifyTemplateFile, "notify-template", "", "Go template file redefining the \"title\" and \"text\" notification templates")
+}
+
+// NewNotifier returns the notifiers and templates selected by the notification flags, or a nil notifier when there are none
+func NewNotifier() (notify.Notifier, *notify.Templates, error) {
+	var templates *notify.Templates
+	if notifyTemplateFile != "" {
+		var err error
+		if templates, err = notify.LoadTemplates(notifyTemplateFile); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(notifyURIs) == 0 && len(notifyTeamURIs) == 0 {
+		return nil, templates, nil
+	}
+	var notifiers notify.Multi
+	for _, uri := range notifyURIs {
+		notifier, err := notify.ParseNotifier(uri)
+		if err != nil {
+			return nil, nil, err
+		}
+		notifiers = append(notifiers, notifier)
+	}
+	if len(notifyTeamURIs) == 0 {
+		return notifiers, templates, nil
+	}
+
+	// Owner teams are routed on top of the targets told about everything
+	teams := map[string]notify.Multi{}
+	for _, route := range notifyTeamURIs {
+		team, uri, found := strings.Cut(route, "=")
+		if !found || team == "" {
+			return nil, nil, fmt.Errorf("invalid --notify-team %q, expected {team}={notifier}", route)
+		}
+		notifier, err := notify.ParseNotifier(uri)
+		if err != nil {
+			return nil, nil, err
+		}
+		teams[team] = append(teams[team], notifier)
+	}
+	router := &notify.Router{Teams: map[string]notify.Notifier{}}
+	if len(notifiers) > 0 {
+		router.Default = notifiers
+	}
+	for team, notifiers := range teams {
+		router.Teams[team] = notifiers
+	}
+	return router, templates, nil
+}
+
+// NewNotifyCommand creates and returns the notify command with its subcommands
+func NewNotifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Check the notifications sent when operations finish or fail",
+		Long: `With --notify, service account creation, bulk provisioning, deletion, apply and the Cloud audit log
+export tell teams how they ended. Notifications are sent by the operations worker, so the webhook URLs and SMTP
+credentials never enter workflow history. Pass --notify to 'worker run' for workflows run by a long-lived worker.
+
+With --notify-team payments=slack+https://..., events about a service account owned by the payments team
+(see service-account create --owner-team) are sent to that team as well.
+
+The message title and text are Go templates over the event (.Operation, .Subject, .Status, .Failed, .Error,
+.WorkflowID, .RunID, .Details, .Time) and can be redefined with --notify-template:
+
+  {{define "title"}}[ops] {{.Operation}} {{.Subject}}: {{.Status}}{{end}}`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newNotifyTestCommand())
+
+	return cmd
+}
+
+// newNotifyTestCommand creates the notify test subcommand
+func newNotifyTestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Send a sample notification to every --notify target",
+		RunE:  runNotifyTest,
+	}
+
+	cmd.Flags().BoolVar(&notifyTestFailed, "failed", false, "Send a sample failure instead of a success")
+	cmd.Flags().StringVar(&notifyTestTeam, "team", "", "Owner team of the sample, to check a --notify-team route")
+
+	return cmd
+}
+
+// runNotifyTest renders a sample event and delivers it directly, without a workflow
+func runNotifyTest(cmd *cobra.Command, args []string) error {
+	notifier, templates, err := NewNotifier()
+	if err != nil {
+		return err
+	}
+	if notifier == nil {
+		return fmt.Errorf("--notify or --notify-team is required")
+	}
+
+	event := &notify.Event{
+		Operation:  "create service account",
+		Subject:    "notify-test",
+		Status:     notify.StatusSucceeded,
+		Team:       notifyTestTeam,
+		WorkflowID: "notify-test",
+		Details:    map[string]string{"account role": "read"},
+		Time:       time.Now(),
+	}
+	if notifyTestFailed {
+		event.Status = notify.StatusFailed
+		event.Error = "sample failure sent by notify test"
+	}
+	message, err := templates.Render(event)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📣 Sending %q...\n", message.Title)
+	if err := notifier.Notify(cmd.Context(), message); err != nil {
+		return fmt.Errorf("failed to notify: %w", err)
+	}
+	fmt.Printf("✅ Notification sent\n")
+	return nil
+}
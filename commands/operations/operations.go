@@ -8,10 +8,33 @@ func NewOperationsCommand() *cobra.Command {
 		Use:   "operations",
 		Short: "Operations related commands",
 		Long:  `Commands for managing temporal jumpstart operations.`,
+		// Telemetry is flushed by ShutdownTelemetry once the command returns
+		PersistentPreRunE: StartTelemetry,
 	}
 
+	// Temporal server flags apply to every subcommand that runs workflows
+	AddTemporalFlags(cmd.PersistentFlags())
+	AddTaskQueueFlags(cmd.PersistentFlags())
+	AddTelemetryFlags(cmd.PersistentFlags())
+	AddAuditFlags(cmd.PersistentFlags())
+	AddPolicyFlags(cmd.PersistentFlags())
+	AddDryRunFlags(cmd.PersistentFlags())
+	AddNotifyFlags(cmd.PersistentFlags())
+
 	// Add subcommands
 	cmd.AddCommand(NewServiceAccountCommand())
+	cmd.AddCommand(NewApplyCommand())
+	cmd.AddCommand(NewPlanCommand())
+	cmd.AddCommand(NewDriftCommand())
+	cmd.AddCommand(NewNamespaceCommand())
+	cmd.AddCommand(NewUserCommand())
+	cmd.AddCommand(NewGroupCommand())
+	cmd.AddCommand(NewNexusEndpointCommand())
+	cmd.AddCommand(NewSecretsCommand())
+	cmd.AddCommand(NewAuditCommand())
+	cmd.AddCommand(NewPolicyCommand())
+	cmd.AddCommand(NewApprovalsCommand())
+	cmd.AddCommand(NewNotifyCommand())
 
 	return cmd
 }
@@ -0,0 +1,26 @@
+package operations
+
+import (
+	"strings"
+
+	"temporal-jumpstart-operations/clientconfig"
+
+	"github.com/spf13/cobra"
+)
+
+// addOutputFlags adds the output format and Kubernetes manifest flags to a command that writes credentials
+func addOutputFlags(cmd *cobra.Command, opts *clientconfig.OutputOptions) {
+	k := opts.Kubernetes
+	cmd.Flags().StringSliceVar(&opts.Formats, "format", []string{clientconfig.FormatEnvConfig},
+		"Output formats: "+strings.Join(clientconfig.Formats, ", ")+" (repeatable; "+clientconfig.FormatSecret+" is plaintext and not allowed with an encrypted output path or recipients)")
+	cmd.Flags().StringVar(&k.Namespace, "k8s-namespace", "", "Kubernetes namespace of the generated manifests")
+	cmd.Flags().StringVar(&k.SecretName, "k8s-secret-name", "", "Name of the Secret holding TEMPORAL_API_KEY (defaults to temporal-credentials)")
+	cmd.Flags().StringVar(&k.ConfigMapName, "k8s-configmap-name", "", "Name of the ConfigMap holding TEMPORAL_ADDRESS (defaults to temporal-config)")
+	cmd.Flags().StringVar(&k.Deployment, "k8s-deployment", "", "Deployment patched by the kustomize overlay")
+	cmd.Flags().StringVar(&k.Container, "k8s-container", "", "Container receiving the env vars (defaults to the deployment name)")
+	cmd.Flags().StringVar(&k.SecretStore, "k8s-secret-store", "", "external-secrets store the ExternalSecret reads from")
+	cmd.Flags().StringVar(&k.SecretStoreKind, "k8s-secret-store-kind", "", "SecretStore or ClusterSecretStore (defaults to ClusterSecretStore)")
+	cmd.Flags().StringVar(&k.RemoteKey, "k8s-remote-key", "", "Path of the API key in the secret store")
+	cmd.Flags().StringVar(&k.RemoteProperty, "k8s-remote-property", "", "Property of the remote secret holding the API key")
+	cmd.Flags().StringVar(&k.KubesealCert, "kubeseal-cert", "", "sealed-secrets controller certificate used to seal the Secret")
+}
@@ -0,0 +1,164 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"temporal-jumpstart-operations/desiredstate"
+	"temporal-jumpstart-operations/policy"
+	"temporal-jumpstart-operations/temporal"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	// Policy flags shared by every command that creates or updates identities
+	policyFile string
+	noPolicy   bool
+
+	// Policy check flags
+	checkDesiredStateFile string
+	checkServiceAccount   policy.ServiceAccount
+	checkApiKey           policy.ApiKey
+)
+
+// AddPolicyFlags adds the flag selecting the organisation policy identities are checked against
+func AddPolicyFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&policyFile, "policy", "", "Policy file service accounts and API keys must satisfy (defaults to <state-dir>/"+policy.DefaultFile+" when it exists)")
+	flags.BoolVar(&noPolicy, "no-policy", false, "Ignore the policy file; 'worker run' needs it to start without one")
+}
+
+// configuredPolicy returns the policy identities are created and updated under, or nil when none is configured
+// or --no-policy is set
+func configuredPolicy() (*policy.Policy, error) {
+	p, _, err := WorkerPolicy()
+	return p, err
+}
+
+// WorkerPolicy returns the policy an operations worker enforces, and whether it may run without one (--no-policy)
+func WorkerPolicy() (*policy.Policy, bool, error) {
+	if noPolicy && policyFile != "" {
+		return nil, false, fmt.Errorf("--policy and --no-policy are mutually exclusive")
+	}
+	if noPolicy {
+		return nil, true, nil
+	}
+	p, err := loadPolicy()
+	return p, false, err
+}
+
+// loadPolicy returns the configured policy, the one in the state directory, or nil when there is none
+func loadPolicy() (*policy.Policy, error) {
+	if policyFile != "" {
+		return policy.Load(policyFile)
+	}
+	dir := stateDir
+	if dir == "" {
+		dir = temporal.DefaultStateDir()
+	}
+	path := filepath.Join(dir, policy.DefaultFile)
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil
+	}
+	return policy.Load(path)
+}
+
+// NewPolicyCommand creates and returns the policy command with its subcommands
+func NewPolicyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Check identities against the organisation policy",
+		Long: `The policy file sets the guardrails every service account and API key must satisfy before it is
+created in Temporal Cloud. It is enforced by service-account create, plan, apply and the operations worker, which
+loads it itself: workflow input cannot bring its own policy. Without a policy file the commands create and update
+identities unchecked, while 'worker run' refuses to start unless --no-policy is passed.
+
+Example policy file:
+
+  serviceAccounts:
+    forbiddenAccountRoles: [admin, owner]
+    requireDescription: true
+  apiKeys:
+    maxLifetime: 90d
+    requireDescription: true
+  teams:
+    - name: billing
+      prefix: billing-
+      namePattern: '^billing-[a-z0-9-]+$'
+      maxKeyLifetime: 30d`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newPolicyCheckCommand())
+
+	return cmd
+}
+
+// newPolicyCheckCommand creates the policy check subcommand
+func newPolicyCheckCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check a desired state file or a service account against the policy without touching Cloud",
+		Example: `  temporal-jumpstart-operations operations policy check --policy policy.yaml -f desired-state.yaml
+  temporal-jumpstart-operations operations policy check --name billing-worker --account-role developer --duration 180d`,
+		RunE: runPolicyCheck,
+	}
+
+	cmd.Flags().StringVarP(&checkDesiredStateFile, "file", "f", "", "Desired state file to check")
+	cmd.Flags().StringVarP(&checkServiceAccount.Name, "name", "n", "", "Service account name to check")
+	cmd.Flags().StringVar(&checkServiceAccount.Description, "description", "", "Service account description")
+	cmd.Flags().StringVar(&checkServiceAccount.AccountRole, "account-role", "read", "Service account account role")
+	cmd.Flags().StringVarP(&checkApiKey.Name, "api-key-name", "k", "", "API key name (defaults to {service_account_name}_key)")
+	cmd.Flags().StringVar(&checkApiKey.Description, "api-key-description", "", "API key description")
+	cmd.Flags().StringVarP(&checkApiKey.Duration, "duration", "d", "1y", "API key duration")
+
+	return cmd
+}
+
+// runPolicyCheck evaluates the policy and prints every violation
+func runPolicyCheck(cmd *cobra.Command, args []string) error {
+	p, err := loadPolicy()
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return fmt.Errorf("no policy found, pass --policy or create %s in the state directory", policy.DefaultFile)
+	}
+	if checkDesiredStateFile == "" && checkServiceAccount.Name == "" {
+		fmt.Printf("✅ Policy is valid\n")
+		return nil
+	}
+
+	var violations policy.Violations
+	if checkDesiredStateFile != "" {
+		if _, err := desiredstate.LoadWithPolicy(checkDesiredStateFile, p); err != nil {
+			var fileViolations policy.Violations
+			if !errors.As(err, &fileViolations) {
+				return err
+			}
+			violations = append(violations, fileViolations...)
+		}
+	}
+	if checkServiceAccount.Name != "" {
+		key := checkApiKey
+		key.ServiceAccountName = checkServiceAccount.Name
+		if key.Name == "" {
+			key.Name = checkServiceAccount.Name + "_key"
+		}
+		violations = append(violations, p.CheckServiceAccount(checkServiceAccount)...)
+		violations = append(violations, p.CheckApiKey(key)...)
+	}
+
+	if len(violations) == 0 {
+		fmt.Printf("✅ No policy violations\n")
+		return nil
+	}
+	fmt.Printf("❌ %d policy violation(s):\n", len(violations))
+	for _, violation := range violations {
+		fmt.Printf("  ! %s\n", violation)
+	}
+	return fmt.Errorf("policy check failed")
+}
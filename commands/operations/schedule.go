@@ -0,0 +1,69 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"temporal-jumpstart-operations/workers"
+
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	sdktemporal "go.temporal.io/sdk/temporal"
+)
+
+// scheduleTaskQueue returns the task queue scheduled workflows run on. A schedule outlives the command that
+// creates it, so its runs go to the shared 'worker run' rather than to a worker in this process.
+func scheduleTaskQueue() (string, error) {
+	if dryRun {
+		return "", fmt.Errorf("scheduled workflows run on the shared worker, which does not dry run; --dry-run is not supported")
+	}
+	if workerTaskQueue != "" {
+		return workerTaskQueue, nil
+	}
+	return workers.DefaultTaskQueue, nil
+}
+
+// upsertSchedule creates the schedule, or replaces the spec and action of the schedule that already has its ID.
+// It reports whether the schedule was created.
+func upsertSchedule(ctx context.Context, c client.Client, options client.ScheduleOptions) (client.ScheduleHandle, bool, error) {
+	handle, err := c.ScheduleClient().Create(ctx, options)
+	if err == nil {
+		return handle, true, nil
+	}
+	if !errors.Is(err, sdktemporal.ErrScheduleAlreadyRunning) {
+		return nil, false, err
+	}
+
+	handle = c.ScheduleClient().GetHandle(ctx, options.ID)
+	err = handle.Update(ctx, client.ScheduleUpdateOptions{
+		DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+			schedule := input.Description.Schedule
+			schedule.Spec = &options.Spec
+			schedule.Action = options.Action
+			return &client.ScheduleUpdate{Schedule: &schedule}, nil
+		},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if options.TriggerImmediately {
+		if err := handle.Trigger(ctx, client.ScheduleTriggerOptions{}); err != nil {
+			return nil, false, err
+		}
+	}
+	return handle, false, nil
+}
+
+// deleteSchedule deletes the schedule with the ID and reports whether there was one
+func deleteSchedule(ctx context.Context, c client.Client, id string) (bool, error) {
+	err := c.ScheduleClient().GetHandle(ctx, id).Delete(ctx)
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to delete schedule %s: %w", id, err)
+	}
+	return true, nil
+}
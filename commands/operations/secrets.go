@@ -0,0 +1,72 @@
+package operations
+
+import (
+	"fmt"
+	"os"
+
+	"temporal-jumpstart-operations/secrets"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Secrets command flags
+	decryptIdentities    []string
+	decryptPassphraseEnv string
+	decryptOutput        string
+)
+
+// NewSecretsCommand creates and returns the secrets command with its subcommands
+func NewSecretsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Work with generated API key files",
+		Long:  `Work with API key files written by service-account create.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newSecretsDecryptCommand())
+
+	return cmd
+}
+
+// newSecretsDecryptCommand creates the secrets decrypt subcommand
+func newSecretsDecryptCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decrypt <key-file>",
+		Short: "Decrypt an encrypted API key file",
+		Long: `Decrypt an API key file encrypted to age or OpenPGP recipients, or with a passphrase.
+The API key is written to stdout unless --output is given.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runSecretsDecrypt,
+	}
+
+	cmd.Flags().StringArrayVarP(&decryptIdentities, "identity", "i", nil, "age identity file or armored OpenPGP private key (repeatable)")
+	cmd.Flags().StringVar(&decryptPassphraseEnv, "passphrase-env", secrets.DefaultPassphraseEnv, "Environment variable holding the key file or OpenPGP private key passphrase")
+	cmd.Flags().StringVarP(&decryptOutput, "output", "o", "", "File to write the API key to, readable only by the current user (optional)")
+
+	return cmd
+}
+
+// runSecretsDecrypt decrypts a key file with the given identities or passphrase
+func runSecretsDecrypt(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	plaintext, err := secrets.Decrypt(data, decryptIdentities, os.Getenv(decryptPassphraseEnv))
+	if err != nil {
+		return err
+	}
+
+	if decryptOutput == "" {
+		fmt.Println(string(plaintext))
+		return nil
+	}
+	if err := os.WriteFile(decryptOutput, plaintext, 0o600); err != nil {
+		return fmt.Errorf("failed to write api key: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "🔓 Decrypted API key written to %s\n", decryptOutput)
+	return nil
+}
@@ -0,0 +1,233 @@
+package operations
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"temporal-jumpstart-operations/temporal"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Server command flags
+	serverDetach      bool
+	serverStopTimeout time.Duration
+)
+
+// NewServerCommand creates and returns the server command with its subcommands
+func NewServerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "server",
+		Short: "Manage the persistent local Temporal server",
+		Long: `Run a long-lived local Temporal server backed by a SQLite file.
+While it is running every operations command reuses it instead of starting a throwaway dev server,
+so workflow history survives and interrupted workflows resume on the next run.`,
+	}
+
+	addStateDirFlag(cmd.PersistentFlags())
+
+	// Add subcommands
+	cmd.AddCommand(newServerStartCommand())
+	cmd.AddCommand(newServerStopCommand())
+	cmd.AddCommand(newServerStatusCommand())
+
+	return cmd
+}
+
+// newServerStartCommand creates the server start subcommand
+func newServerStartCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start the persistent local Temporal server",
+		RunE:  runServerStart,
+	}
+
+	addTemporalServiceFlags(cmd.Flags())
+	cmd.Flags().BoolVarP(&serverDetach, "detach", "d", false, "Run the server in the background, logging to the state dir")
+
+	return cmd
+}
+
+// newServerStopCommand creates the server stop subcommand
+func newServerStopCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the persistent local Temporal server",
+		RunE:  runServerStop,
+	}
+
+	cmd.Flags().DurationVar(&serverStopTimeout, "timeout", 30*time.Second, "How long to wait for the server to stop")
+
+	return cmd
+}
+
+// newServerStatusCommand creates the server status subcommand
+func newServerStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the persistent local Temporal server",
+		RunE:  runServerStatus,
+	}
+}
+
+// runServerStart runs the persistent server in the foreground until interrupted, or detaches it
+func runServerStart(cmd *cobra.Command, args []string) error {
+	if serverDetach {
+		return startDetachedServer()
+	}
+
+	release, err := temporal.AcquireServerLock(stateDir)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	options, err := temporalServiceOptionsFromFlags()
+	if err != nil {
+		return err
+	}
+	if options.DBFilename == "" {
+		options.DBFilename = temporal.ServerDBFile(stateDir)
+	}
+
+	fmt.Printf("🏗️  Starting persistent TemporalService (db %s)...\n", options.DBFilename)
+	temporalService, err := temporal.NewTemporalServiceWithOptions(options)
+	if err != nil {
+		return fmt.Errorf("failed to create TemporalService: %w", err)
+	}
+	defer func() {
+		if stopErr := temporalService.Stop(); stopErr != nil {
+			fmt.Fprintf(os.Stderr, "Error stopping TemporalService: %v\n", stopErr)
+		}
+	}()
+
+	info := &temporal.ServerInfo{
+		Pid:        os.Getpid(),
+		Address:    temporalService.GetFrontendHostPort(),
+		Namespace:  temporalService.GetNamespace(),
+		UIAddress:  temporalService.GetUIHostPort(),
+		DBFilename: options.DBFilename,
+		StartedAt:  time.Now().UTC(),
+	}
+	if err := temporal.WriteServerInfo(stateDir, info); err != nil {
+		return fmt.Errorf("failed to write server info: %w", err)
+	}
+	printServerInfo(info)
+	fmt.Printf("   Press Ctrl+C to stop\n")
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	select {
+	case <-signals:
+	case <-cmd.Context().Done():
+	}
+
+	fmt.Printf("\n🛑 Stopping persistent TemporalService...\n")
+	return nil
+}
+
+// startDetachedServer re-runs 'server start' without --detach in the background and waits until it is up
+func startDetachedServer() error {
+	if info, err := temporal.ReadServerInfo(stateDir); err == nil {
+		return fmt.Errorf("a persistent TemporalService is already running (pid %d)", info.Pid)
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(stateDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	logFile, err := os.OpenFile(temporal.ServerLogFile(stateDir), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open server log: %w", err)
+	}
+	defer logFile.Close()
+
+	var childArgs []string
+	for _, arg := range os.Args[1:] {
+		if arg == "-d" || arg == "--detach" || strings.HasPrefix(arg, "--detach=") {
+			continue
+		}
+		childArgs = append(childArgs, arg)
+	}
+	child := exec.Command(executable, childArgs...)
+	child.Stdout = logFile
+	child.Stderr = logFile
+	child.SysProcAttr = detachedProcAttr()
+	if err := child.Start(); err != nil {
+		return fmt.Errorf("failed to start server: %w", err)
+	}
+
+	fmt.Printf("🏗️  Starting persistent TemporalService in the background (pid %d)...\n", child.Process.Pid)
+	exited := make(chan error, 1)
+	go func() { exited <- child.Wait() }()
+
+	// the first start may download the Temporal CLI
+	deadline := time.After(5 * time.Minute)
+	for {
+		select {
+		case err := <-exited:
+			return fmt.Errorf("server exited during startup (%v), see %s", err, temporal.ServerLogFile(stateDir))
+		case <-deadline:
+			return fmt.Errorf("server did not start in time, see %s", temporal.ServerLogFile(stateDir))
+		case <-time.After(500 * time.Millisecond):
+			if info, err := temporal.ReadServerInfo(stateDir); err == nil {
+				printServerInfo(info)
+				fmt.Printf("   Logs: %s\n", temporal.ServerLogFile(stateDir))
+				return nil
+			}
+		}
+	}
+}
+
+// runServerStop stops the persistent server
+func runServerStop(cmd *cobra.Command, args []string) error {
+	info, err := temporal.StopServer(stateDir, serverStopTimeout)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🛑 Stopped persistent TemporalService (pid %d)\n", info.Pid)
+	return nil
+}
+
+// runServerStatus shows the persistent server and checks it is reachable
+func runServerStatus(cmd *cobra.Command, args []string) error {
+	info, err := temporal.ReadServerInfo(stateDir)
+	if err == temporal.ErrServerNotRunning {
+		fmt.Printf("⚪ No persistent TemporalService is running (state dir %s)\n", stateDir)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	printServerInfo(info)
+
+	temporalService, err := temporal.Connect(temporal.ConnectOptions{Address: info.Address, Namespace: info.Namespace})
+	if err != nil {
+		fmt.Printf("   Health: ❌ %v\n", err)
+		return nil
+	}
+	defer temporalService.Stop()
+	fmt.Printf("   Health: ✅ serving\n")
+	return nil
+}
+
+// printServerInfo prints where the persistent server is running
+func printServerInfo(info *temporal.ServerInfo) {
+	fmt.Printf("✅ Persistent TemporalService running (pid %d)\n", info.Pid)
+	fmt.Printf("   Address: %s\n", info.Address)
+	fmt.Printf("   Namespace: %s\n", info.Namespace)
+	if info.UIAddress != "" {
+		fmt.Printf("   Web UI: http://%s\n", info.UIAddress)
+	}
+	fmt.Printf("   Database: %s\n", info.DBFilename)
+	fmt.Printf("   Started: %s\n", info.StartedAt.Local().Format(time.RFC3339))
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package operations
+
+import "syscall"
+
+// detachedProcAttr returns no extra attributes where sessions are not supported
+func detachedProcAttr() *syscall.SysProcAttr {
+	return nil
+}
@@ -2,22 +2,33 @@ package operations
 
 import (
 	"fmt"
-	"os"
+	"path/filepath"
+	"strings"
 
+	"temporal-jumpstart-operations/clientconfig"
+	"temporal-jumpstart-operations/ownership"
+	"temporal-jumpstart-operations/policy"
+	"temporal-jumpstart-operations/secrets"
 	"temporal-jumpstart-operations/temporal"
+	"temporal-jumpstart-operations/workflows"
+	"temporal-jumpstart-operations/workflows/activities"
 
 	"github.com/spf13/cobra"
-
-	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
-	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
 )
 
 var (
 	// Create command flags
 	outputPath         string
+	configPath         string
+	recipients         []string
 	serviceAccountName string
+	description        string
 	apiKeyName         string
+	apiKeyDescription  string
 	duration           string
+	accountRole        string
+	namespaceAccess    []string
+	outputOptions      = &clientconfig.OutputOptions{Kubernetes: &clientconfig.KubernetesOptions{}}
 
 	// Delete command flags
 	deleteServiceAccountName string
@@ -31,9 +42,13 @@ func NewServiceAccountCommand() *cobra.Command {
 		Long:  `Manage service accounts in Temporal Cloud for jumpstart operations.`,
 	}
 
+	cmd.PersistentFlags().StringVar(&ownerRegistry, "owner-registry", "", "Local owner registry file (defaults to <state-dir>/"+ownership.DefaultRegistryFile+")")
+
 	// Add subcommands
 	cmd.AddCommand(newCreateCommand())
 	cmd.AddCommand(newDeleteCommand())
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newSetOwnerCommand())
 
 	return cmd
 }
@@ -43,19 +58,40 @@ func newCreateCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create Temporal Cloud service account and API key",
-		Long:  `Create a service account and API key in Temporal Cloud for jumpstart operations.`,
-		RunE:  runCreateServiceAccount,
+		Long: `Create a service account and API key in Temporal Cloud for jumpstart operations.
+
+With --from-file every service account of a CSV or YAML manifest is created by its own child workflow,
+at most --max-concurrent at a time, and a summary of every row is printed. Empty manifest fields fall back
+to the flags. A CSV manifest has a header row with any of the columns name, description, accountRole,
+namespaces (ns1=write;ns2=read), apiKeyName, apiKeyDescription, duration, outputPath, ownerTeam, ownerContact,
+costCenter and tickets (OPS-1;OPS-2); a YAML manifest lists the same fields under serviceAccounts, with the
+ownership fields under owner as team, contact, costCenter and tickets.
+
+The owner is recorded in a tag appended to the description, e.g.
+'Billing worker [owner team=payments; contact=payments@example.com; costCenter=CC-42; tickets=OPS-1]',
+so service-account list --owner finds it and notifications reach the team's --notify-team target.`,
+		Example: `  temporal-jumpstart-operations operations service-account create -n billing-worker -o ./secrets
+  temporal-jumpstart-operations operations service-account create --from-file accounts.csv -o ./secrets --report provision.json`,
+		RunE: runCreateServiceAccount,
 	}
 
 	// Define flags for the create command
-	cmd.Flags().StringVarP(&outputPath, "output-path", "o", "", "Output path (required)")
-	cmd.Flags().StringVarP(&serviceAccountName, "name", "n", "", "Service account name (required)")
+	cmd.Flags().StringVarP(&outputPath, "output-path", "o", "", "Output path or secret sink URI: file://, encfile://, dotenv://, vault+https://, exec:// (required, unless every manifest row sets outputPath)")
+	cmd.Flags().StringVar(&configPath, "config-path", "", "Directory for generated configuration (optional, defaults to the output path directory)")
+	cmd.Flags().StringArrayVar(&recipients, "recipient", nil, "age public key, age recipients file or OpenPGP public key file to encrypt the key file to (optional, repeatable)")
+	cmd.Flags().StringVarP(&serviceAccountName, "name", "n", "", "Service account name (required unless --from-file is used)")
+	cmd.Flags().StringVar(&description, "description", "", "Service account description (optional, defaults to 'Service account for temporal jumpstart operations - {service_account_name}')")
 	cmd.Flags().StringVarP(&apiKeyName, "api-key-name", "k", "", "API key name (optional, defaults to {service_account_name}_key)")
+	cmd.Flags().StringVar(&apiKeyDescription, "api-key-description", "", "API key description (optional, defaults to 'API key for service account {service_account_name}')")
 	cmd.Flags().StringVarP(&duration, "duration", "d", "1y", "Duration (optional, defaults to '1y')")
-
-	// Mark required flags
-	cmd.MarkFlagRequired("output-path")
-	cmd.MarkFlagRequired("name")
+	cmd.Flags().StringVar(&accountRole, "account-role", "read", "Account role: owner, admin, developer, finance_admin or read (optional, defaults to 'read')")
+	cmd.Flags().StringArrayVar(&namespaceAccess, "namespace", nil, "Namespace permission as {namespace}={admin|write|read} (optional, repeatable)")
+	cmd.Flags().StringVar(&manifestFile, "from-file", "", "CSV or YAML manifest of service accounts to create instead of --name")
+	cmd.Flags().IntVar(&maxConcurrent, "max-concurrent", 5, "Service accounts of a manifest created at the same time")
+	cmd.Flags().StringVar(&provisionReportFile, "report", "", "Write the JSON summary of a manifest to this file (optional)")
+	addOwnerFlags(cmd, owner)
+	addOutputFlags(cmd, outputOptions)
+	cmd.MarkFlagsMutuallyExclusive("from-file", "name")
 
 	return cmd
 }
@@ -80,6 +116,10 @@ func newDeleteCommand() *cobra.Command {
 
 // runCreateServiceAccount contains the main logic for creating a service account
 func runCreateServiceAccount(cmd *cobra.Command, args []string) error {
+	if manifestFile != "" {
+		return runCreateServiceAccountsFromFile(cmd, args)
+	}
+
 	// Set default api_key_name if not provided
 	if apiKeyName == "" {
 		apiKeyName = serviceAccountName + "_key"
@@ -87,18 +127,97 @@ func runCreateServiceAccount(cmd *cobra.Command, args []string) error {
 
 	// Validate required arguments
 	if outputPath == "" {
-		return fmt.Errorf("output_path is required")
+		return fmt.Errorf("--output-path is required")
 	}
 	if serviceAccountName == "" {
-		return fmt.Errorf("service_account_name is required")
+		return fmt.Errorf("--name or --from-file is required")
+	}
+	namespacePermissions, err := activities.ParseNamespacePermissions(namespaceAccess)
+	if err != nil {
+		return err
+	}
+	access := &activities.Access{
+		AccountRole:          accountRole,
+		NamespacePermissions: namespacePermissions,
+	}
+	if _, err := access.ToIdentityAccess(); err != nil {
+		return err
+	}
+	sink, err := secrets.ParseSink(outputPath)
+	if err != nil {
+		return err
+	}
+	if sink, err = secrets.WithRecipients(sink, recipients); err != nil {
+		return err
+	}
+	if configPath == "" {
+		configPath = secrets.LocalDir(outputPath)
+	}
+	if err := outputOptions.Validate(); err != nil {
+		return err
+	}
+	if err := activities.ValidateSecretOutput(sink, outputOptions); err != nil {
+		return err
+	}
+	if configPath == "" && outputOptions.HasKubernetes() {
+		return fmt.Errorf("--config-path is required for the Kubernetes formats when --output-path is not a local path")
+	}
+	if err := owner.Validate(); err != nil {
+		return err
+	}
+
+	// Enforce the organisation policy before anything reaches Cloud, and before descriptions are defaulted
+	p, err := configuredPolicy()
+	if err != nil {
+		return err
+	}
+	violations := p.CheckServiceAccount(policy.ServiceAccount{Name: serviceAccountName, Description: description, AccountRole: accountRole})
+	violations = append(violations, p.CheckApiKey(policy.ApiKey{
+		ServiceAccountName: serviceAccountName,
+		Name:               apiKeyName,
+		Description:        apiKeyDescription,
+		Duration:           duration,
+	})...)
+	if err := violations.Err(); err != nil {
+		return err
+	}
+
+	// A privileged service account is created by the workflow, which waits for approval when the worker's
+	// policy marks its Cloud account as production
+	if policy.IsPrivilegedAccountRole(accountRole) {
+		return runApprovedCreateServiceAccount(cmd, &workflows.CreateServiceAccountRequest{
+			OutputPath:           outputPath,
+			ConfigPath:           configPath,
+			Recipients:           recipients,
+			ServiceAccountName:   serviceAccountName,
+			Description:          description,
+			APIKeyName:           apiKeyName,
+			APIKeyDescription:    apiKeyDescription,
+			Duration:             duration,
+			AccountRole:          accountRole,
+			NamespacePermissions: namespacePermissions,
+			Output:               outputOptions,
+			Owner:                owner,
+		})
+	}
+
+	if description == "" {
+		description = fmt.Sprintf("Service account for temporal jumpstart operations - %s", serviceAccountName)
+	}
+	if apiKeyDescription == "" {
+		apiKeyDescription = fmt.Sprintf("API key for service account %s", serviceAccountName)
 	}
 
 	// Display the parsed arguments
 	fmt.Printf("Configuration:\n")
 	fmt.Printf("  Output Path: %s\n", outputPath)
+	fmt.Printf("  Config Path: %s\n", configPath)
 	fmt.Printf("  Service Account Name: %s\n", serviceAccountName)
 	fmt.Printf("  API Key Name: %s\n", apiKeyName)
 	fmt.Printf("  Duration: %s\n", duration)
+	fmt.Printf("  Access: %s\n", access)
+	fmt.Printf("  Owner: %s\n", owner)
+	fmt.Printf("  Formats: %s\n", strings.Join(outputOptions.Formats, ", "))
 
 	// Create Cloud Service client
 	fmt.Printf("\n🔗 Connecting to Temporal Cloud...\n")
@@ -110,83 +229,195 @@ func runCreateServiceAccount(cmd *cobra.Command, args []string) error {
 
 	ctx := cmd.Context()
 
-	// Create service account in Temporal Cloud
-	fmt.Printf("📝 Creating service account '%s' in Temporal Cloud...\n", serviceAccountName)
-	serviceAccountDescription := fmt.Sprintf("Service account for temporal jumpstart operations - %s", serviceAccountName)
+	acts := newActivities(cloudService)
 
-	serviceAccountReq := &cloudservicev1.CreateServiceAccountRequest{
-		Spec: &identityv1.ServiceAccountSpec{
-			Name:        serviceAccountName,
-			Description: serviceAccountDescription,
-		},
+	// Make sure the service account will be able to reach its namespaces
+	if namespaces := access.Namespaces(); len(namespaces) > 0 {
+		fmt.Printf("🔍 Validating namespaces %v...\n", namespaces)
+		if err := acts.ValidateNamespaces(ctx, &activities.ValidateNamespacesRequest{
+			Namespaces: namespaces,
+		}); err != nil {
+			return fmt.Errorf("failed to validate namespaces: %w", err)
+		}
 	}
 
-	serviceAccountResp, err := cloudService.CreateServiceAccount(ctx, serviceAccountReq)
+	// Create service account in Temporal Cloud; the activity refuses names that are already taken
+	fmt.Printf("📝 Creating service account '%s' in Temporal Cloud...\n", serviceAccountName)
+	serviceAccountResp, err := acts.CreateServiceAccount(ctx, &activities.CreateServiceAccountRequest{
+		Name:        serviceAccountName,
+		Description: ownership.EncodeDescription(description, owner),
+		Access:      access,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create service account: %w", err)
 	}
 
+	if err := waitForAsyncOperation(ctx, cloudService, serviceAccountResp.AsyncOperationId, userOperationTimeout); err != nil {
+		return fmt.Errorf("failed to create service account: %w", err)
+	}
+
 	fmt.Printf("✅ Created service account: %s (ID: %s)\n", serviceAccountName, serviceAccountResp.ServiceAccountId)
 
-	// Create API key for the service account
+	// Create API key for the service account; the token is written to the output path by the activity
 	fmt.Printf("🔑 Creating API key '%s' for service account...\n", apiKeyName)
-	apiKeyDescription := fmt.Sprintf("API key for service account %s", serviceAccountName)
+	apiKeyResp, err := acts.CreateAPIKey(ctx, &activities.CreateAPIKeyRequest{
+		ServiceAccountId: serviceAccountResp.ServiceAccountId,
+		Name:             apiKeyName,
+		Description:      apiKeyDescription,
+		Duration:         duration,
+		OutputPath:       outputPath,
+		ConfigPath:       configPath,
+		Recipients:       recipients,
+		Output:           outputOptions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	if err := waitForAsyncOperation(ctx, cloudService, apiKeyResp.AsyncOperationId, userOperationTimeout); err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
 
-	// TODO: Implement API key creation using the embedded CloudServiceClient
-	// This will use cloudClient.CreateApiKey() method once we implement the proper request structure
-	fmt.Printf("NOTE: API key creation will be implemented using cloudClient.CreateApiKey()\n")
-	fmt.Printf("  Service Account ID: %s\n", serviceAccountResp.ServiceAccountId)
-	fmt.Printf("  API Key Name: %s\n", apiKeyName)
-	fmt.Printf("  Description: %s\n", apiKeyDescription)
-	fmt.Printf("  Duration: %s\n", duration)
+	fmt.Printf("✅ Created API key: %s (ID: %s)\n", apiKeyName, apiKeyResp.ApiKeyId)
+
+	fmt.Printf("  API Key Secret: %s\n", apiKeyResp.SecretLocation)
+
+	// Secrets sent to a remote sink have no local directory to put configuration next to
+	if configPath == "" {
+		fmt.Printf("\n🎉 Initialization completed successfully!\n")
+		fmt.Printf("   Service Account: %s (created in Temporal Cloud)\n", serviceAccountName)
+		fmt.Printf("   API Key: %s (written to %s)\n", apiKeyName, apiKeyResp.SecretLocation)
+		return nil
+	}
 
-	// Create TemporalService (local dev server)
-	fmt.Printf("\n🏗️  Initializing local TemporalService...\n")
-	temporalService, err := temporal.NewTemporalService()
+	// The 'local' profile points at the persistent local TemporalService, unless an existing cluster is used.
+	// A throwaway server would be gone before the profile is used, so it never gets one.
+	remote, err := remoteConnectOptions()
 	if err != nil {
-		return fmt.Errorf("failed to create TemporalService: %w", err)
+		return err
 	}
-	defer func() {
-		if stopErr := temporalService.Stop(); stopErr != nil {
-			fmt.Fprintf(os.Stderr, "Error stopping TemporalService: %v\n", stopErr)
+	localAddress := ""
+	if remote == nil {
+		if info, err := temporal.ReadServerInfo(stateDir); err == nil {
+			localAddress = info.Address
+			fmt.Printf("♻️  Writing a 'local' profile for the persistent TemporalService at %s\n", localAddress)
+		} else {
+			fmt.Printf("ℹ️  No persistent TemporalService running, skipping the 'local' profile (start one with 'server start')\n")
 		}
-	}()
+	}
 
-	fmt.Printf("✅ TemporalService started successfully!\n")
-	fmt.Printf("  Frontend Address: %s\n", temporalService.GetFrontendHostPort())
-	fmt.Printf("  Port: %d\n", temporalService.GetPort())
+	// Encrypted key files cannot be read by the SDK, so the profiles only reference plaintext ones
+	plaintextKeyFile := ""
+	if len(recipients) == 0 {
+		plaintextKeyFile = secrets.KeyFile(outputPath, apiKeyName)
+	}
 
-	// TODO: Generate configuration files and save to output path
+	// Generate the client envconfig profiles for Cloud and the persistent local server
 	fmt.Printf("\n📂 Generating configuration files...\n")
-	fmt.Printf("  Output Path: %s\n", outputPath)
-	fmt.Printf("NOTE: Configuration file generation will be implemented next\n")
+	clientConfig, err := acts.WriteClientConfig(ctx, &activities.WriteClientConfigRequest{
+		OutputPath:   configPath,
+		ApiKeyFile:   plaintextKeyFile,
+		Namespaces:   access.Namespaces(),
+		LocalAddress: localAddress,
+		Output:       outputOptions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate configuration files: %w", err)
+	}
+	if clientConfig.ConfigFile != "" {
+		fmt.Printf("  Client Config: %s (profiles: %s)\n", clientConfig.ConfigFile, strings.Join(clientConfig.Profiles, ", "))
+	}
+	if outputOptions.HasKubernetes() {
+		fmt.Printf("  Kubernetes Manifests: %s\n", filepath.Join(configPath, clientconfig.KubernetesDir))
+	}
 
 	fmt.Printf("\n🎉 Initialization completed successfully!\n")
 	fmt.Printf("   Service Account: %s (created in Temporal Cloud)\n", serviceAccountName)
 	fmt.Printf("   API Key: %s (created for service account)\n", apiKeyName)
-	fmt.Printf("   Local Server: %s (running)\n", temporalService.GetFrontendHostPort())
+	if localAddress != "" {
+		fmt.Printf("   Local Server: %s (persistent)\n", localAddress)
+	}
+	if clientConfig.ConfigFile != "" {
+		fmt.Printf("   Use a profile with: TEMPORAL_CONFIG_FILE=%s TEMPORAL_PROFILE=<profile>\n", clientConfig.ConfigFile)
+	}
+
+	return nil
+}
+
+// runApprovedCreateServiceAccount creates a service account through the CreateOperationsServiceAccount workflow,
+// which waits for approval before the service account reaches Cloud
+func runApprovedCreateServiceAccount(cmd *cobra.Command, request *workflows.CreateServiceAccountRequest) error {
+	fmt.Printf("\n🔗 Connecting to Temporal Cloud...\n")
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	workflowID := fmt.Sprintf("create-service-account-%s", request.ServiceAccountName)
+	if request.ApprovalRequest, err = approvalRequest(cmd.Context(), cloudService); err != nil {
+		return err
+	}
+	if request.RequireApproval {
+		fmt.Printf("⏳ Account role %s needs approval, approvers run: operations approvals approve %s\n", request.AccountRole, operationsWorkflowID(workflowID))
+	}
+	if err := runOperationsWorkflow(cmd.Context(), cloudService, workflowID, workflows.CreateOperationsServiceAccount, request, nil); err != nil {
+		return err
+	}
 
+	fmt.Printf("\n🎉 Initialization completed successfully!\n")
+	fmt.Printf("   Service Account: %s (created in Temporal Cloud)\n", request.ServiceAccountName)
+	fmt.Printf("   API Key: %s (written to %s)\n", request.APIKeyName, request.OutputPath)
 	return nil
 }
 
-// runDeleteServiceAccount contains the main logic for deleting a service account
+// runDeleteServiceAccount deletes a service account through the DeleteOperationsServiceAccount workflow,
+// which waits for approval first on production Cloud accounts
 func runDeleteServiceAccount(cmd *cobra.Command, args []string) error {
 	// Validate required arguments
 	if deleteServiceAccountName == "" {
 		return fmt.Errorf("service account name is required")
 	}
 
+	registry, err := ownership.LoadRegistry(ownerRegistryFile())
+	if err != nil {
+		return err
+	}
+
 	// Display the operation
 	fmt.Printf("🗑️  Deleting service account '%s' from Temporal Cloud...\n", deleteServiceAccountName)
 
-	// TODO: Implement service account deletion
-	// This will require:
-	// 1. Create Cloud Service client
-	// 2. List service accounts to find the one with matching name
-	// 3. Delete the service account by ID
-	fmt.Printf("NOTE: Service account deletion will be implemented using the Cloud Operations API\n")
-	fmt.Printf("  Service Account Name: %s\n", deleteServiceAccountName)
-	fmt.Printf("  Implementation pending: Need to lookup service account by name, then delete by ID\n")
+	fmt.Printf("\n🔗 Connecting to Temporal Cloud...\n")
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	workflowID := fmt.Sprintf("delete-service-account-%s", deleteServiceAccountName)
+	approval, err := approvalRequest(cmd.Context(), cloudService)
+	if err != nil {
+		return err
+	}
+	if approval.RequireApproval {
+		fmt.Printf("⏳ Waiting for approval, approvers run: operations approvals approve %s\n", operationsWorkflowID(workflowID))
+	}
+	var result workflows.DeleteServiceAccountResult
+	if err := runOperationsWorkflow(cmd.Context(), cloudService, workflowID, workflows.DeleteOperationsServiceAccount, &workflows.DeleteServiceAccountRequest{
+		ServiceAccountName: deleteServiceAccountName,
+		ApprovalRequest:    approval,
+		Owner:              registry.Lookup(deleteServiceAccountName),
+	}, &result); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Deleted service account: %s (ID: %s)\n", deleteServiceAccountName, result.ServiceAccountId)
 
-	return fmt.Errorf("delete functionality not yet implemented - please use tcld or the Temporal Cloud UI to delete service accounts")
+	// The owner of a deleted service account is of no use anymore
+	if registry.Lookup(deleteServiceAccountName) != nil && !dryRun {
+		registry.Set(deleteServiceAccountName, nil)
+		if err := registry.Save(ownerRegistryFile()); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,312 @@
+package operations
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"temporal-jumpstart-operations/ownership"
+	"temporal-jumpstart-operations/policy"
+	"temporal-jumpstart-operations/secrets"
+	"temporal-jumpstart-operations/workflows"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// Bulk create flags
+	manifestFile        string
+	maxConcurrent       int
+	provisionReportFile string
+)
+
+// serviceAccountManifest is a --from-file YAML manifest
+type serviceAccountManifest struct {
+	ServiceAccounts []serviceAccountManifestRow `yaml:"serviceAccounts"`
+}
+
+// serviceAccountManifestRow is one service account of a manifest; empty fields fall back to the create flags
+type serviceAccountManifestRow struct {
+	Name              string            `yaml:"name"`
+	Description       string            `yaml:"description"`
+	AccountRole       string            `yaml:"accountRole"`
+	Namespaces        map[string]string `yaml:"namespaces"`
+	ApiKeyName        string            `yaml:"apiKeyName"`
+	ApiKeyDescription string            `yaml:"apiKeyDescription"`
+	Duration          string            `yaml:"duration"`
+	OutputPath        string            `yaml:"outputPath"`
+	Owner             *ownership.Owner  `yaml:"owner"`
+}
+
+// manifestColumns are the CSV header names, matching the YAML field names
+var manifestColumns = []string{"name", "description", "accountRole", "namespaces", "apiKeyName", "apiKeyDescription", "duration", "outputPath",
+	"ownerTeam", "ownerContact", "costCenter", "tickets"}
+
+// loadServiceAccountManifest reads a CSV or YAML manifest, chosen by the file extension
+func loadServiceAccountManifest(path string) ([]serviceAccountManifestRow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rows, err := parseServiceAccountCSV(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+		}
+		return rows, nil
+	case ".yaml", ".yml":
+		var manifest serviceAccountManifest
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+		}
+		return manifest.ServiceAccounts, nil
+	default:
+		return nil, fmt.Errorf("unsupported manifest %s, expected a .csv, .yaml or .yml file", path)
+	}
+}
+
+// parseServiceAccountCSV reads a manifest with a header row. Namespaces are written as ns1=write;ns2=read
+// and tickets as OPS-1;OPS-2.
+func parseServiceAccountCSV(r io.Reader) ([]serviceAccountManifestRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("a header row is required")
+	}
+
+	columns := map[string]int{}
+	for i, header := range records[0] {
+		column := ""
+		for _, known := range manifestColumns {
+			if strings.EqualFold(strings.TrimSpace(header), known) {
+				column = known
+			}
+		}
+		if column == "" {
+			return nil, fmt.Errorf("unknown column %q, expected %s", header, strings.Join(manifestColumns, ", "))
+		}
+		columns[column] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("a name column is required")
+	}
+
+	var rows []serviceAccountManifestRow
+	for line, record := range records[1:] {
+		value := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := serviceAccountManifestRow{
+			Name:              value("name"),
+			Description:       value("description"),
+			AccountRole:       value("accountRole"),
+			ApiKeyName:        value("apiKeyName"),
+			ApiKeyDescription: value("apiKeyDescription"),
+			Duration:          value("duration"),
+			OutputPath:        value("outputPath"),
+		}
+		rowOwner := &ownership.Owner{
+			Team:       value("ownerTeam"),
+			Contact:    value("ownerContact"),
+			CostCenter: value("costCenter"),
+		}
+		if tickets := value("tickets"); tickets != "" {
+			rowOwner.Tickets = strings.Split(tickets, ";")
+		}
+		if !rowOwner.IsZero() {
+			row.Owner = rowOwner
+		}
+		if namespaces := value("namespaces"); namespaces != "" {
+			if row.Namespaces, err = activities.ParseNamespacePermissions(strings.Split(namespaces, ";")); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line+2, err)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// serviceAccountRequests turns manifest rows into CreateOperationsServiceAccount requests, filling in the create flags.
+// Each service account gets its own configuration directory so their temporal.toml files do not overwrite each other.
+func serviceAccountRequests(rows []serviceAccountManifestRow) ([]*workflows.CreateServiceAccountRequest, error) {
+	// Each row is checked against the policy by its own workflow, so one violation does not fail the others
+	if _, err := configuredPolicy(); err != nil {
+		return nil, err
+	}
+	flagNamespaces, err := activities.ParseNamespacePermissions(namespaceAccess)
+	if err != nil {
+		return nil, err
+	}
+	if err := outputOptions.Validate(); err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	var requests []*workflows.CreateServiceAccountRequest
+	for i, row := range rows {
+		if row.Name == "" {
+			return nil, fmt.Errorf("row %d: name is required", i+1)
+		}
+		if names[strings.ToLower(row.Name)] {
+			return nil, fmt.Errorf("service account %s is listed more than once", row.Name)
+		}
+		names[strings.ToLower(row.Name)] = true
+
+		request := &workflows.CreateServiceAccountRequest{
+			OutputPath:           firstNonEmpty(row.OutputPath, outputPath),
+			Recipients:           recipients,
+			ServiceAccountName:   row.Name,
+			Description:          row.Description,
+			APIKeyName:           row.ApiKeyName,
+			APIKeyDescription:    row.ApiKeyDescription,
+			Duration:             firstNonEmpty(row.Duration, duration),
+			AccountRole:          firstNonEmpty(row.AccountRole, accountRole),
+			NamespacePermissions: row.Namespaces,
+			Output:               outputOptions,
+			Owner:                row.Owner.Merge(owner),
+		}
+		if request.Owner.IsZero() {
+			request.Owner = nil
+		}
+		if err := request.Owner.Validate(); err != nil {
+			return nil, fmt.Errorf("service account %s: %w", row.Name, err)
+		}
+		if request.NamespacePermissions == nil {
+			request.NamespacePermissions = flagNamespaces
+		}
+		if request.OutputPath == "" {
+			return nil, fmt.Errorf("service account %s: an outputPath or --output-path is required", row.Name)
+		}
+		sink, err := secrets.ParseSink(request.OutputPath)
+		if err != nil {
+			return nil, fmt.Errorf("service account %s: %w", row.Name, err)
+		}
+		if sink, err = secrets.WithRecipients(sink, recipients); err != nil {
+			return nil, err
+		}
+		if err := activities.ValidateSecretOutput(sink, request.Output); err != nil {
+			return nil, fmt.Errorf("service account %s: %w", row.Name, err)
+		}
+		if _, err := request.Access().ToIdentityAccess(); err != nil {
+			return nil, fmt.Errorf("service account %s: %w", row.Name, err)
+		}
+		if _, err := activities.ParseDuration(request.Duration); err != nil {
+			return nil, fmt.Errorf("service account %s: %w", row.Name, err)
+		}
+		if dir := firstNonEmpty(configPath, secrets.LocalDir(request.OutputPath)); dir != "" {
+			request.ConfigPath = filepath.Join(dir, row.Name)
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// runCreateServiceAccountsFromFile provisions every service account of a manifest through the ProvisionServiceAccounts workflow
+func runCreateServiceAccountsFromFile(cmd *cobra.Command, args []string) error {
+	rows, err := loadServiceAccountManifest(manifestFile)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("manifest %s lists no service accounts", manifestFile)
+	}
+	requests, err := serviceAccountRequests(rows)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📋 Provisioning %d service account(s) from %s, at most %d at a time\n", len(requests), manifestFile, maxConcurrent)
+
+	fmt.Printf("\n🔗 Connecting to Temporal Cloud...\n")
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	// Rows granting a privileged account role wait for approval, requested by whoever runs the command
+	privileged := func(account *workflows.CreateServiceAccountRequest) bool {
+		return policy.IsPrivilegedAccountRole(account.AccountRole)
+	}
+	if slices.ContainsFunc(requests, privileged) {
+		approval, err := approvalRequest(cmd.Context(), cloudService)
+		if err != nil {
+			return err
+		}
+		for _, account := range requests {
+			if privileged(account) {
+				account.ApprovalRequest = approval
+			}
+		}
+	}
+
+	request := &workflows.ProvisionServiceAccountsRequest{
+		Accounts:      requests,
+		MaxConcurrent: maxConcurrent,
+	}
+	workflowID, err := requestWorkflowID("provision-service-accounts", request)
+	if err != nil {
+		return err
+	}
+	var result workflows.ProvisionServiceAccountsResult
+	if err := runOperationsWorkflow(cmd.Context(), cloudService, workflowID, workflows.ProvisionServiceAccounts, request, &result); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n📊 Provisioning summary: %d created, %d already existed, %d failed\n", result.Created, result.Exists, result.Failed)
+	for _, row := range result.Results {
+		switch row.Status {
+		case workflows.ProvisionStatusCreated:
+			fmt.Printf("  ✅ %s\n", row.ServiceAccountName)
+		case workflows.ProvisionStatusExists:
+			fmt.Printf("  ⏭️  %s (already exists)\n", row.ServiceAccountName)
+		default:
+			fmt.Printf("  ❌ %s: %s\n", row.ServiceAccountName, row.Error)
+		}
+	}
+
+	if provisionReportFile != "" {
+		report, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		if err := os.WriteFile(provisionReportFile, append(report, '\n'), 0o644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		fmt.Printf("   Report: %s\n", provisionReportFile)
+	}
+
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of %d service accounts failed", result.Failed, len(result.Results))
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first value that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
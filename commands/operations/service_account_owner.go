@@ -0,0 +1,132 @@
+package operations
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"temporal-jumpstart-operations/ownership"
+	"temporal-jumpstart-operations/temporal"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Ownership flags shared by create and set-owner
+	owner = &ownership.Owner{}
+
+	// Owner registry flag shared by the service-account subcommands
+	ownerRegistry string
+
+	// List command flags
+	listOwner string
+
+	// Set owner command flags
+	setOwnerServiceAccountName string
+)
+
+// addOwnerFlags adds the flags describing who owns a service account
+func addOwnerFlags(cmd *cobra.Command, o *ownership.Owner) {
+	cmd.Flags().StringVar(&o.Team, "owner-team", "", "Team owning the service account (optional)")
+	cmd.Flags().StringVar(&o.Contact, "owner-contact", "", "How to reach the owner, e.g. an email address or chat channel (optional)")
+	cmd.Flags().StringVar(&o.CostCenter, "cost-center", "", "Cost center the service account is billed to (optional)")
+	cmd.Flags().StringArrayVar(&o.Tickets, "ticket", nil, "Ticket reference the service account is created or changed for (optional, repeatable)")
+}
+
+// ownerRegistryFile returns the configured owner registry, the one in the state directory by default
+func ownerRegistryFile() string {
+	if ownerRegistry != "" {
+		return ownerRegistry
+	}
+	dir := stateDir
+	if dir == "" {
+		dir = temporal.DefaultStateDir()
+	}
+	return filepath.Join(dir, ownership.DefaultRegistryFile)
+}
+
+// newListCommand creates the service-account list subcommand
+func newListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List Temporal Cloud service accounts and their owners",
+		Long: `List service accounts with their account role and owner. The owner is read from the owner tag of the
+description, completed from the local owner registry that set-owner writes.`,
+		Example: `  temporal-jumpstart-operations operations service-account list --owner payments`,
+		RunE:    runListServiceAccounts,
+	}
+
+	cmd.Flags().StringVar(&listOwner, "owner", "", "Only list service accounts whose owner team or contact is this (optional)")
+
+	return cmd
+}
+
+// newSetOwnerCommand creates the service-account set-owner subcommand
+func newSetOwnerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-owner",
+		Short: "Record the owner of a service account in the local owner registry",
+		Long: `Record the owner of an existing service account in the local owner registry, without changing it in
+Temporal Cloud. Service accounts created with the ownership flags carry their owner in the description instead.
+Without any ownership flag the service account is removed from the registry.`,
+		RunE: runSetOwner,
+	}
+
+	cmd.Flags().StringVarP(&setOwnerServiceAccountName, "name", "n", "", "Service account name (required)")
+	addOwnerFlags(cmd, owner)
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// runListServiceAccounts prints every service account, or those of one owner
+func runListServiceAccounts(cmd *cobra.Command, args []string) error {
+	registry, err := ownership.LoadRegistry(ownerRegistryFile())
+	if err != nil {
+		return err
+	}
+
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	serviceAccounts, err := activities.ListServiceAccounts(cmd.Context(), cloudService)
+	if err != nil {
+		return fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	for _, sa := range serviceAccounts {
+		spec := sa.GetSpec()
+		saOwner := registry.Resolve(spec.GetName(), spec.GetDescription())
+		if listOwner != "" && !saOwner.Matches(listOwner) {
+			continue
+		}
+		fmt.Printf("%s\t%s\t%s\t%s\n", sa.GetId(), spec.GetName(), activities.AccessFromIdentity(spec.GetAccess()), saOwner)
+	}
+	return nil
+}
+
+// runSetOwner records or removes the owner of a service account in the local registry
+func runSetOwner(cmd *cobra.Command, args []string) error {
+	if err := owner.Validate(); err != nil {
+		return err
+	}
+	path := ownerRegistryFile()
+	registry, err := ownership.LoadRegistry(path)
+	if err != nil {
+		return err
+	}
+
+	registry.Set(setOwnerServiceAccountName, owner)
+	if err := registry.Save(path); err != nil {
+		return err
+	}
+
+	if owner.IsZero() {
+		fmt.Printf("🗑️  Removed the owner of %s from %s\n", setOwnerServiceAccountName, path)
+	} else {
+		fmt.Printf("✅ Recorded the owner of %s in %s: %s\n", setOwnerServiceAccountName, path, owner)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"temporal-jumpstart-operations/telemetry"
+	"temporal-jumpstart-operations/temporal"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+)
+
+var (
+	// Telemetry flags shared by every command that runs workflows
+	telemetryOptions telemetry.Options
+
+	// telemetryProvider is set once StartTelemetry exported to a collector
+	telemetryProvider *telemetry.Provider
+)
+
+// AddTelemetryFlags adds the flags exporting metrics and traces over OTLP
+func AddTelemetryFlags(flags *pflag.FlagSet) {
+	o := &telemetryOptions
+	flags.StringVar(&o.Endpoint, "otlp-endpoint", "", "OTLP gRPC collector host:port, e.g. the Datadog Agent on localhost:4317 (defaults to OTEL_EXPORTER_OTLP_ENDPOINT, disabled when unset)")
+	flags.BoolVar(&o.Insecure, "otlp-insecure", false, "Connect to the OTLP collector without TLS")
+	flags.StringVar(&o.ServiceName, "otel-service-name", "", "service.name reported with metrics and traces (defaults to OTEL_SERVICE_NAME or "+telemetry.DefaultServiceName+")")
+	flags.DurationVar(&o.MetricsInterval, "otlp-metrics-interval", 10*time.Second, "How often metrics are exported")
+}
+
+// StartTelemetry starts exporting metrics and traces when a collector is configured.
+// It is meant as a PersistentPreRunE; ShutdownTelemetry flushes what was recorded.
+func StartTelemetry(cmd *cobra.Command, args []string) error {
+	if telemetryProvider != nil || !telemetryOptions.Enabled() {
+		return nil
+	}
+	provider, err := telemetry.Setup(cmd.Context(), telemetryOptions)
+	if err != nil {
+		return err
+	}
+	telemetryProvider = provider
+	endpoint := telemetryOptions.Endpoint
+	if endpoint == "" {
+		endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	fmt.Printf("📈 Exporting metrics and traces to %s\n", endpoint)
+	return nil
+}
+
+// ShutdownTelemetry flushes and stops the exporters started by StartTelemetry
+func ShutdownTelemetry() {
+	if telemetryProvider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := telemetryProvider.Shutdown(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Failed to flush telemetry: %v\n", err)
+	}
+	telemetryProvider = nil
+}
+
+// withConnectTelemetry adds the SDK metrics handler and tracing interceptor to an existing cluster connection
+func withConnectTelemetry(options temporal.ConnectOptions) temporal.ConnectOptions {
+	if telemetryProvider != nil {
+		options.MetricsHandler = telemetryProvider.MetricsHandler()
+		options.Interceptors = append(options.Interceptors, telemetryProvider.TracingInterceptor())
+	}
+	return options
+}
+
+// withServiceTelemetry adds the SDK metrics handler and tracing interceptor to a local TemporalService
+func withServiceTelemetry(options temporal.TemporalServiceOptions) temporal.TemporalServiceOptions {
+	if telemetryProvider != nil {
+		options.MetricsHandler = telemetryProvider.MetricsHandler()
+		options.Interceptors = append(options.Interceptors, telemetryProvider.TracingInterceptor())
+	}
+	return options
+}
+
+// cloudDialOptions returns the gRPC dial options instrumenting Cloud API calls
+func cloudDialOptions() []grpc.DialOption {
+	if telemetryProvider == nil {
+		return nil
+	}
+	return telemetryProvider.GRPCDialOptions()
+}
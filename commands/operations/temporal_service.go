@@ -0,0 +1,205 @@
+package operations
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"temporal-jumpstart-operations/clientconfig"
+	"temporal-jumpstart-operations/temporal"
+
+	"github.com/spf13/pflag"
+)
+
+var (
+	// TemporalService flags shared by every command that starts a local dev server
+	temporalServiceOptions temporal.TemporalServiceOptions
+	devDynamicConfig       []string
+	devSearchAttributes    []string
+
+	// Persistent server discovery flags
+	stateDir  string
+	ephemeral bool
+
+	// Existing cluster flags
+	connectOptions     temporal.ConnectOptions
+	temporalProfile    string
+	temporalConfigFile string
+)
+
+// addTemporalConnectFlags adds the flags that point commands at an existing Temporal cluster or Cloud namespace
+func addTemporalConnectFlags(flags *pflag.FlagSet) {
+	o := &connectOptions
+	flags.StringVar(&o.Address, "temporal-address", "", "Existing Temporal frontend host:port to run operations workflows on instead of a local server")
+	flags.StringVar(&o.Namespace, "temporal-namespace", "", "Namespace on the existing Temporal server (defaults to 'default')")
+	flags.StringVar(&o.APIKey, "temporal-api-key", "", "API key for the existing Temporal server (defaults to TEMPORAL_API_KEY when an address or profile is set)")
+	flags.BoolVar(&o.TLS, "temporal-tls", false, "Connect to the existing Temporal server with TLS (implied by an API key or client certificate)")
+	flags.StringVar(&o.TLSServerName, "temporal-tls-server-name", "", "Server name verified by TLS")
+	flags.StringVar(&o.TLSServerCACertPath, "temporal-tls-ca-cert", "", "CA certificate bundle for the existing Temporal server")
+	flags.StringVar(&o.TLSClientCertPath, "temporal-tls-cert", "", "mTLS client certificate")
+	flags.StringVar(&o.TLSClientKeyPath, "temporal-tls-key", "", "mTLS client private key")
+	flags.StringVar(&temporalProfile, "temporal-profile", "", "envconfig profile to connect with, e.g. one written by service-account create")
+	flags.StringVar(&temporalConfigFile, "temporal-config-file", "", "envconfig file holding the profile (defaults to TEMPORAL_CONFIG_FILE or the Temporal CLI config)")
+}
+
+// remoteConnectOptions resolves the existing cluster to connect to from the profile and flags.
+// It returns nil when no existing cluster was requested.
+func remoteConnectOptions() (*temporal.ConnectOptions, error) {
+	if temporalProfile == "" && connectOptions.Address == "" {
+		return nil, nil
+	}
+	options := connectOptions
+
+	// flags override the profile
+	if temporalProfile != "" {
+		configFile := temporalConfigFile
+		if configFile == "" {
+			configFile = clientconfig.DefaultConfigFile()
+		}
+		profile, apiKey, err := clientconfig.LoadProfile(configFile, temporalProfile)
+		if err != nil {
+			return nil, err
+		}
+		if options.Address == "" {
+			options.Address = profile.Address
+		}
+		if options.Namespace == "" {
+			options.Namespace = profile.Namespace
+		}
+		if options.APIKey == "" {
+			options.APIKey = apiKey
+		}
+		if profile.TLS != nil && !profile.TLS.Disabled {
+			options.TLS = true
+			if options.TLSServerName == "" {
+				options.TLSServerName = profile.TLS.ServerName
+			}
+			if options.TLSServerCACertPath == "" {
+				options.TLSServerCACertPath = profile.TLS.ServerCACertPath
+			}
+			if options.TLSClientCertPath == "" {
+				options.TLSClientCertPath = profile.TLS.ClientCertPath
+				options.TLSClientKeyPath = profile.TLS.ClientKeyPath
+			}
+		}
+	}
+	if options.APIKey == "" {
+		options.APIKey = os.Getenv("TEMPORAL_API_KEY")
+	}
+	return &options, nil
+}
+
+// AddTemporalFlags adds the flags selecting the Temporal server commands run on:
+// an existing cluster, the persistent local server or a throwaway local dev server
+func AddTemporalFlags(flags *pflag.FlagSet) {
+	addTemporalServiceFlags(flags)
+	addStateDirFlag(flags)
+	addTemporalConnectFlags(flags)
+	flags.BoolVar(&ephemeral, "ephemeral", false, "Start a throwaway local Temporal server even when the persistent one is running")
+}
+
+// addStateDirFlag adds the flag locating the persistent server state
+func addStateDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(&stateDir, "state-dir", temporal.DefaultStateDir(), "Directory holding the persistent local Temporal server state")
+}
+
+// addTemporalServiceFlags adds the local TemporalService flags
+func addTemporalServiceFlags(flags *pflag.FlagSet) {
+	o := &temporalServiceOptions
+	flags.IntVar(&o.Port, "dev-port", 0, "Local Temporal server frontend port (defaults to a free port)")
+	flags.BoolVar(&o.EnableUI, "dev-ui", false, "Start the local Temporal server Web UI")
+	flags.IntVar(&o.UIPort, "dev-ui-port", 0, "Local Temporal server Web UI port (defaults to a free port)")
+	flags.StringVar(&o.DBFilename, "dev-db-file", "", "SQLite file persisting the local Temporal server (defaults to in-memory)")
+	flags.StringArrayVar(&o.ExtraNamespaces, "dev-namespace", nil, "Extra namespace to register on the local Temporal server (repeatable)")
+	flags.StringArrayVar(&devDynamicConfig, "dev-dynamic-config-value", nil, "Dynamic config value as KEY=VALUE, e.g. system.enableNexus=true (repeatable)")
+	flags.StringArrayVar(&devSearchAttributes, "dev-search-attribute", nil,
+		"Search attribute to register as NAME=TYPE, TYPE is one of "+strings.Join(temporal.SearchAttributeTypes, ", ")+" (repeatable)")
+	flags.StringVar(&o.LogLevel, "dev-log-level", "", "Local Temporal server log level: "+strings.Join(temporal.LogLevels, ", ")+" (defaults to warn)")
+	flags.StringVar(&o.CLIVersion, "dev-cli-version", "", "Temporal CLI version to download for the local server, e.g. v1.3.0")
+	flags.StringVar(&o.CLIPath, "dev-cli-path", "", "Existing Temporal CLI binary to run the local server with")
+}
+
+// temporalServiceOptionsFromFlags builds the local TemporalService options from the flags
+func temporalServiceOptionsFromFlags() (temporal.TemporalServiceOptions, error) {
+	options := temporalServiceOptions
+	var err error
+	if options.DynamicConfigValues, err = temporal.ParseKeyValues(devDynamicConfig); err != nil {
+		return options, fmt.Errorf("invalid --dev-dynamic-config-value: %w", err)
+	}
+	if options.SearchAttributes, err = temporal.ParseKeyValues(devSearchAttributes); err != nil {
+		return options, fmt.Errorf("invalid --dev-search-attribute: %w", err)
+	}
+	return options, nil
+}
+
+// NewTemporalService connects to the existing cluster given by --temporal-address or --temporal-profile,
+// otherwise it reuses the persistent local TemporalService when one is running,
+// otherwise it starts a throwaway one configured by the flags
+func NewTemporalService() (temporal.Service, error) {
+	remote, err := remoteConnectOptions()
+	if err != nil {
+		return nil, err
+	}
+	if remote != nil {
+		temporalService, err := temporal.Connect(withConnectTelemetry(*remote))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("🔗 Using Temporal server at %s (namespace %s)\n", temporalService.GetFrontendHostPort(), temporalService.GetNamespace())
+		return temporalService, nil
+	}
+
+	if !ephemeral {
+		temporalService, err := temporal.DiscoverTemporalServiceWithOptions(stateDir, withConnectTelemetry(temporal.ConnectOptions{}))
+		if err == nil {
+			fmt.Printf("♻️  Reusing persistent TemporalService at %s\n", temporalService.GetFrontendHostPort())
+			return temporalService, nil
+		}
+		if err != temporal.ErrServerNotRunning {
+			fmt.Fprintf(os.Stderr, "⚠️  Persistent TemporalService unavailable, starting a local one: %v\n", err)
+		}
+	}
+
+	options, err := temporalServiceOptionsFromFlags()
+	if err != nil {
+		return nil, err
+	}
+	temporalService, err := temporal.NewTemporalServiceWithOptions(withServiceTelemetry(options))
+	if err != nil {
+		return nil, err
+	}
+	if ui := temporalService.GetUIHostPort(); ui != "" {
+		fmt.Printf("🖥️  Temporal Web UI at http://%s\n", ui)
+	}
+	return temporalService, nil
+}
+
+// NewDurableTemporalService connects to the existing cluster or to the persistent local TemporalService.
+// It never starts a throwaway server: what the purpose leaves behind, e.g. a schedule, has to outlive the command.
+func NewDurableTemporalService(purpose string) (temporal.Service, error) {
+	remote, err := remoteConnectOptions()
+	if err != nil {
+		return nil, err
+	}
+	if remote != nil {
+		return NewTemporalService()
+	}
+	if ephemeral {
+		return nil, fmt.Errorf("%s needs a Temporal server that outlives this command, --ephemeral is not supported", purpose)
+	}
+	temporalService, err := temporal.DiscoverTemporalServiceWithOptions(stateDir, withConnectTelemetry(temporal.ConnectOptions{}))
+	if err == temporal.ErrServerNotRunning {
+		return nil, fmt.Errorf("%s needs a Temporal server that outlives this command: run 'server start' or set --temporal-address or --temporal-profile", purpose)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("persistent TemporalService unavailable: %w", err)
+	}
+	fmt.Printf("♻️  Reusing persistent TemporalService at %s\n", temporalService.GetFrontendHostPort())
+	return temporalService, nil
+}
+
+// isDurable reports whether the Temporal server outlives this command, i.e. it is not a throwaway dev server
+func isDurable(temporalService temporal.Service) bool {
+	_, ok := temporalService.(*temporal.RemoteService)
+	return ok
+}
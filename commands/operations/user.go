@@ -0,0 +1,362 @@
+package operations
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"temporal-jumpstart-operations/workflows"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// User command flags
+	userEmail           string
+	userAccountRole     string
+	userNamespaceAccess []string
+	userReplaceAccess   bool
+	userImportFile      string
+)
+
+// userOperationTimeout bounds how long user and group commands wait for Cloud to finish
+const userOperationTimeout = 5 * time.Minute
+
+// NewUserCommand creates and returns the user command with its subcommands
+func NewUserCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage Temporal Cloud users",
+		Long:  `Invite, update and remove Temporal Cloud users and onboard them in bulk from a CSV file.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newUserInviteCommand())
+	cmd.AddCommand(newUserListCommand())
+	cmd.AddCommand(newUserSetAccessCommand())
+	cmd.AddCommand(newUserRemoveCommand())
+	cmd.AddCommand(newUserImportCommand())
+
+	return cmd
+}
+
+// addAccessFlags defines the account role and namespace permission flags
+func addAccessFlags(cmd *cobra.Command, role *string, namespaces *[]string) {
+	cmd.Flags().StringVar(role, "account-role", "read", "Account role: owner, admin, developer, finance_admin or read")
+	cmd.Flags().StringArrayVar(namespaces, "namespace", nil, "Namespace permission as {namespace}={admin|write|read} (repeatable)")
+}
+
+// accessFromFlags parses the account role and namespace permission flags
+func accessFromFlags(role string, namespaces []string) (*activities.Access, error) {
+	permissions, err := activities.ParseNamespacePermissions(namespaces)
+	if err != nil {
+		return nil, err
+	}
+	access := &activities.Access{
+		AccountRole:          role,
+		NamespacePermissions: permissions,
+	}
+	if _, err := access.ToIdentityAccess(); err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
+// newUserInviteCommand creates the user invite subcommand
+func newUserInviteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "invite",
+		Short: "Invite a user to Temporal Cloud",
+		RunE:  runUserInvite,
+	}
+
+	cmd.Flags().StringVarP(&userEmail, "email", "e", "", "User email (required)")
+	addAccessFlags(cmd, &userAccountRole, &userNamespaceAccess)
+	cmd.MarkFlagRequired("email")
+
+	return cmd
+}
+
+// newUserListCommand creates the user list subcommand
+func newUserListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List Temporal Cloud users",
+		RunE:  runUserList,
+	}
+}
+
+// newUserSetAccessCommand creates the user set-access subcommand
+func newUserSetAccessCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-access",
+		Short: "Assign the account role and namespace permissions of a user",
+		Long: `Set the account role and namespace permissions of a user to the ones given.
+
+The permissions on namespaces that are not given are kept, unless --replace-namespaces is set.`,
+		RunE: runUserSetAccess,
+	}
+
+	cmd.Flags().StringVarP(&userEmail, "email", "e", "", "User email (required)")
+	addAccessFlags(cmd, &userAccountRole, &userNamespaceAccess)
+	cmd.Flags().BoolVar(&userReplaceAccess, "replace-namespaces", false, "Remove the permissions on namespaces that are not given")
+	cmd.MarkFlagRequired("email")
+
+	return cmd
+}
+
+// newUserRemoveCommand creates the user remove subcommand
+func newUserRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a user from Temporal Cloud",
+		RunE:  runUserRemove,
+	}
+
+	cmd.Flags().StringVarP(&userEmail, "email", "e", "", "User email (required)")
+	cmd.MarkFlagRequired("email")
+
+	return cmd
+}
+
+// newUserImportCommand creates the user import subcommand
+func newUserImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Invite users in bulk from a CSV file",
+		Long: `Invite users, assign their roles and add them to groups from a CSV file through the ImportUsers workflow.
+Existing users keep their account role unless the row sets one, namespace permissions are only replaced
+when the row lists some, and existing group memberships are left alone. The CSV needs a header row with these columns:
+
+  email,account_role,namespaces,groups
+  jane@example.com,developer,billing.a1b2c=write;orders.a1b2c=read,platform;oncall`,
+		RunE: runUserImport,
+	}
+
+	cmd.Flags().StringVarP(&userImportFile, "file", "f", "", "CSV file (required)")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// runUserInvite invites a single user and waits for the invitation
+func runUserInvite(cmd *cobra.Command, args []string) error {
+	access, err := accessFromFlags(userAccountRole, userNamespaceAccess)
+	if err != nil {
+		return err
+	}
+
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	fmt.Printf("✉️  Inviting '%s' (%s)...\n", userEmail, access)
+	resp, err := newActivities(cloudService).InviteUser(ctx, &activities.InviteUserRequest{
+		Email:  userEmail,
+		Access: access,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to invite user: %w", err)
+	}
+	if err := waitForAsyncOperation(ctx, cloudService, resp.AsyncOperationId, userOperationTimeout); err != nil {
+		return fmt.Errorf("failed to invite user: %w", err)
+	}
+
+	fmt.Printf("✅ Invited user: %s (ID: %s)\n", userEmail, resp.UserId)
+	return nil
+}
+
+// runUserList prints every user in the account
+func runUserList(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	users, err := activities.ListUsers(cmd.Context(), cloudService)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+	for _, user := range users {
+		fmt.Printf("%s\t%s\t%s\t%s\n", user.Id, user.GetSpec().GetEmail(), user.State.String(),
+			activities.AccessFromIdentity(user.GetSpec().GetAccess()))
+	}
+	return nil
+}
+
+// runUserSetAccess replaces the access of a user and waits for the update
+func runUserSetAccess(cmd *cobra.Command, args []string) error {
+	access, err := accessFromFlags(userAccountRole, userNamespaceAccess)
+	if err != nil {
+		return err
+	}
+
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	fmt.Printf("🔐 Setting access of '%s' to %s...\n", userEmail, access)
+	resp, err := newActivities(cloudService).SetUserAccess(ctx, &activities.SetUserAccessRequest{
+		Email:             userEmail,
+		Access:            access,
+		ReplaceNamespaces: userReplaceAccess,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set user access: %w", err)
+	}
+	if err := waitForAsyncOperation(ctx, cloudService, resp.AsyncOperationId, userOperationTimeout); err != nil {
+		return fmt.Errorf("failed to set user access: %w", err)
+	}
+
+	fmt.Printf("✅ Updated access of user: %s\n", userEmail)
+	return nil
+}
+
+// runUserRemove removes a user and waits for the removal
+func runUserRemove(cmd *cobra.Command, args []string) error {
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	ctx := cmd.Context()
+	fmt.Printf("🗑️  Removing user '%s' from Temporal Cloud...\n", userEmail)
+	resp, err := newActivities(cloudService).RemoveUser(ctx, &activities.RemoveUserRequest{
+		Email: userEmail,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove user: %w", err)
+	}
+	if err := waitForAsyncOperation(ctx, cloudService, resp.AsyncOperationId, userOperationTimeout); err != nil {
+		return fmt.Errorf("failed to remove user: %w", err)
+	}
+
+	fmt.Printf("✅ Removed user: %s\n", userEmail)
+	return nil
+}
+
+// runUserImport reads the CSV file and runs the ImportUsers workflow
+func runUserImport(cmd *cobra.Command, args []string) error {
+	rows, err := readUserImportFile(userImportFile)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("📋 Importing %d user(s) from %s\n", len(rows), userImportFile)
+
+	cloudService, closer, err := NewCloudServiceClient()
+	if err != nil {
+		return fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer closer.Close()
+
+	request := &workflows.ImportUsersRequest{
+		Users: rows,
+	}
+	workflowID, err := requestWorkflowID("import-users", request)
+	if err != nil {
+		return err
+	}
+	var result workflows.ImportUsersResult
+	if err := runOperationsWorkflow(cmd.Context(), cloudService, workflowID, workflows.ImportUsers, request, &result); err != nil {
+		return err
+	}
+
+	failed := 0
+	fmt.Printf("\n")
+	for _, r := range result.Results {
+		if r.Status == workflows.UserImportFailed {
+			failed++
+			fmt.Printf("  ❌ %s: %s\n", r.Email, r.Error)
+			continue
+		}
+		fmt.Printf("  ✅ %s: %s\n", r.Email, r.Status)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d user(s) failed to import", failed, len(result.Results))
+	}
+	fmt.Printf("\n🎉 Imported %d user(s) successfully!\n", len(result.Results))
+	return nil
+}
+
+// readUserImportFile parses the user import CSV into workflow rows
+func readUserImportFile(path string) ([]workflows.UserImportRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open user import file: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user import header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, fmt.Errorf("user import file must have an email column")
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []workflows.UserImportRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user import file: %w", err)
+		}
+
+		row := workflows.UserImportRow{
+			Email:       field(record, "email"),
+			AccountRole: field(record, "account_role"),
+		}
+		if row.Email == "" {
+			return nil, fmt.Errorf("line %d: email is required", line)
+		}
+		if row.NamespacePermissions, err = activities.ParseNamespacePermissions(splitList(field(record, "namespaces"))); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if _, err := activities.ParseAccountRole(row.AccountRole); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		row.Groups = splitList(field(record, "groups"))
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("user import file %s has no users", path)
+	}
+	return rows, nil
+}
+
+// splitList splits a semicolon separated CSV cell, dropping empty entries
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
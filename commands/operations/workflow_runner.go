@@ -0,0 +1,254 @@
+package operations
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+
+	"temporal-jumpstart-operations/temporal"
+	"temporal-jumpstart-operations/workers"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"github.com/spf13/pflag"
+	enumspb "go.temporal.io/api/enums/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+)
+
+// workerTaskQueue is the task queue of a shared 'worker run' the workflows are dispatched to instead of an in-process worker
+var workerTaskQueue string
+
+// AddTaskQueueFlags adds the flag dispatching operations workflows to a shared worker
+func AddTaskQueueFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&workerTaskQueue, "task-queue", "",
+		"Task queue of a running 'worker run' to dispatch operations workflows to instead of running them in this process, e.g. "+workers.DefaultTaskQueue)
+}
+
+// operationsTaskQueue is the task queue the workflows started by this CLI run on. The CLI's own worker polls it,
+// so it is never the shared queue 'worker run' polls, where that worker could pick up a CLI workflow and act on it
+// with its own credentials and configuration; --task-queue hands workflows to that worker deliberately.
+// It is stable per user and host, so running an interrupted command again reaches the workflow it left running.
+func operationsTaskQueue() string {
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-cli-%s@%s", workers.DefaultTaskQueue, name, host)
+}
+
+const (
+	// inputHashMemoKey is the memo field holding the hash of the workflow input, see inputHash
+	inputHashMemoKey = "inputHash"
+	// startRequestMemoKey is the memo field identifying the command that started the workflow
+	startRequestMemoKey = "startRequest"
+)
+
+// inputHash returns a short stable hash of the workflow input
+func inputHash(args interface{}) (string, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode workflow input: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// requestWorkflowID derives a workflow ID from the request for operations that have no natural name
+func requestWorkflowID(prefix string, request interface{}) (string, error) {
+	hash, err := inputHash(request)
+	if err != nil {
+		return "", err
+	}
+	return prefix + "-" + hash, nil
+}
+
+// checkAttachedInput reports whether the run was started by an earlier command rather than by startRequest,
+// and fails when that run was started with different input
+func checkAttachedInput(ctx context.Context, c client.Client, run client.WorkflowRun, hash string, startRequest string) (bool, error) {
+	describe, err := c.DescribeWorkflowExecution(ctx, run.GetID(), run.GetRunID())
+	if err != nil {
+		return false, fmt.Errorf("failed to describe workflow %s: %w", run.GetID(), err)
+	}
+	if queue := describe.GetExecutionConfig().GetTaskQueue().GetName(); queue != operationsWorkerTaskQueue() {
+		return false, fmt.Errorf("workflow %s (run %s) is already running on task queue %s, which this command does not serve",
+			run.GetID(), run.GetRunID(), queue)
+	}
+	memo := map[string]string{}
+	for _, key := range []string{inputHashMemoKey, startRequestMemoKey} {
+		if payload, ok := describe.GetWorkflowExecutionInfo().GetMemo().GetFields()[key]; ok {
+			var value string
+			if err := converter.GetDefaultDataConverter().FromPayload(payload, &value); err != nil {
+				return false, fmt.Errorf("failed to decode the %s memo of workflow %s: %w", key, run.GetID(), err)
+			}
+			memo[key] = value
+		}
+	}
+	if memo[startRequestMemoKey] == startRequest {
+		return false, nil
+	}
+	if memo[inputHashMemoKey] != hash {
+		return false, fmt.Errorf("workflow %s (run %s) is already running with different arguments, wait for it to finish or terminate it",
+			run.GetID(), run.GetRunID())
+	}
+	return true, nil
+}
+
+// operationsRuntime is a Temporal server with an in-process operations worker,
+// or without one when the workflows are dispatched to a shared worker with --task-queue
+type operationsRuntime struct {
+	temporalService temporal.Service
+	cloudService    cloudservicev1.CloudServiceClient
+	worker          *workers.OperationsWorker
+}
+
+// startOperationsRuntime starts or connects to a Temporal server and an operations worker polling it
+func startOperationsRuntime(cloudService cloudservicev1.CloudServiceClient) (*operationsRuntime, error) {
+	if workerTaskQueue != "" {
+		// a throwaway server would never be polled by the shared worker
+		return startDurableOperationsRuntime(cloudService, "--task-queue")
+	}
+	return startOperationsRuntimeOn(NewTemporalService, cloudService)
+}
+
+// startDurableOperationsRuntime is startOperationsRuntime on a server that outlives the command, see NewDurableTemporalService
+func startDurableOperationsRuntime(cloudService cloudservicev1.CloudServiceClient, purpose string) (*operationsRuntime, error) {
+	return startOperationsRuntimeOn(func() (temporal.Service, error) {
+		return NewDurableTemporalService(purpose)
+	}, cloudService)
+}
+
+// startOperationsRuntimeOn starts an operations worker polling the Temporal server newService returns
+func startOperationsRuntimeOn(newService func() (temporal.Service, error), cloudService cloudservicev1.CloudServiceClient) (*operationsRuntime, error) {
+	fmt.Printf("\n🏗️  Initializing local TemporalService...\n")
+	temporalService, err := newService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TemporalService: %w", err)
+	}
+	fmt.Printf("✅ TemporalService ready at %s\n", temporalService.GetFrontendHostPort())
+
+	if workerTaskQueue != "" {
+		if dryRun {
+			temporalService.Stop()
+			return nil, fmt.Errorf("--dry-run runs the workflows on an in-process worker and cannot be combined with --task-queue")
+		}
+		fmt.Printf("📨 Dispatching workflows to the worker polling task queue %s\n", workerTaskQueue)
+		return &operationsRuntime{temporalService: temporalService, cloudService: cloudService}, nil
+	}
+
+	notifier, templates, err := NewNotifier()
+	if err != nil {
+		temporalService.Stop()
+		return nil, err
+	}
+	// the policy is only enforced when one is configured, unlike on the shared 'worker run'
+	p, err := configuredPolicy()
+	if err != nil {
+		temporalService.Stop()
+		return nil, err
+	}
+	w, err := workers.NewOperationsWorker(temporalService.GetClient(), workers.OperationsWorkerOptions{
+		CloudClient:     cloudService,
+		TaskQueue:       operationsWorkerTaskQueue(),
+		DryRun:          dryRun,
+		Notifier:        notifier,
+		NotifyTemplates: templates,
+		Policy:          p,
+		NoPolicy:        p == nil,
+	})
+	if err == nil {
+		err = w.Start()
+	}
+	if err != nil {
+		temporalService.Stop()
+		return nil, fmt.Errorf("failed to start operations worker: %w", err)
+	}
+
+	return &operationsRuntime{
+		temporalService: temporalService,
+		cloudService:    cloudService,
+		worker:          w,
+	}, nil
+}
+
+// Activities returns the worker's activities, bound to the Cloud client and the Temporal server
+func (r *operationsRuntime) Activities() *activities.Activities {
+	if r.worker != nil {
+		return r.worker.Activities()
+	}
+	acts := newActivities(r.cloudService)
+	acts.OperatorClient = r.Client().OperatorService()
+	return acts
+}
+
+// Client returns the Temporal client connected to the Temporal server
+func (r *operationsRuntime) Client() client.Client {
+	return r.temporalService.GetClient()
+}
+
+// Stop stops the worker and the Temporal server if this process started it
+func (r *operationsRuntime) Stop() {
+	if r.worker != nil {
+		r.worker.Stop()
+	}
+	if err := r.temporalService.Stop(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error stopping TemporalService: %v\n", err)
+	}
+}
+
+// runOperationsWorkflow starts a local operations runtime, executes the workflow to completion
+// and decodes its result into valuePtr (which may be nil)
+func runOperationsWorkflow(ctx context.Context, cloudService cloudservicev1.CloudServiceClient, workflowID string, workflowFunc interface{}, args interface{}, valuePtr interface{}) error {
+	runtime, err := startOperationsRuntime(cloudService)
+	if err != nil {
+		return err
+	}
+	defer runtime.Stop()
+	return executeOperationsWorkflow(ctx, runtime, workflowID, workflowFunc, args, valuePtr)
+}
+
+// executeOperationsWorkflow executes the workflow on a started operations runtime, see runOperationsWorkflow
+func executeOperationsWorkflow(ctx context.Context, runtime *operationsRuntime, workflowID string, workflowFunc interface{}, args interface{}, valuePtr interface{}) error {
+	hash, err := inputHash(args)
+	if err != nil {
+		return err
+	}
+
+	// Workflow IDs are derived from the request, so running an interrupted command again on the persistent server
+	// or an existing cluster attaches to the workflow it left running instead of repeating the operation
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	startRequest := hex.EncodeToString(nonce)
+	run, err := runtime.Client().ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:                       operationsWorkflowID(workflowID),
+		TaskQueue:                operationsWorkerTaskQueue(),
+		WorkflowIDConflictPolicy: enumspb.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING,
+		Memo:                     map[string]interface{}{inputHashMemoKey: hash, startRequestMemoKey: startRequest},
+	}, workflowFunc, args)
+	if err != nil {
+		return fmt.Errorf("failed to start workflow: %w", err)
+	}
+
+	attached, err := checkAttachedInput(ctx, runtime.Client(), run, hash, startRequest)
+	if err != nil {
+		return err
+	}
+	if attached {
+		fmt.Printf("↩️  Attached to running workflow %s (run %s)\n", run.GetID(), run.GetRunID())
+	} else {
+		fmt.Printf("▶️  Started workflow %s (run %s)\n", run.GetID(), run.GetRunID())
+	}
+
+	if err := run.Get(ctx, valuePtr); err != nil {
+		return fmt.Errorf("workflow %s failed: %w", run.GetID(), err)
+	}
+	return nil
+}
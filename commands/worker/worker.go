@@ -0,0 +1,188 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"temporal-jumpstart-operations/commands/operations"
+	"temporal-jumpstart-operations/secrets"
+	"temporal-jumpstart-operations/workers"
+
+	"github.com/spf13/cobra"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	sdkworker "go.temporal.io/sdk/worker"
+)
+
+var (
+	// Run command flags
+	taskQueue                   string
+	identity                    string
+	maxConcurrentActivities     int
+	maxConcurrentWorkflowTasks  int
+	maxConcurrentActivityPoller int
+	maxConcurrentWorkflowPoller int
+	drainTimeout                time.Duration
+	healthAddress               string
+	allowExecSinks              bool
+	secretDirs                  []string
+	noCloud                     bool
+)
+
+// NewWorkerCommand creates and returns the worker command with its subcommands
+func NewWorkerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "worker",
+		Short: "Run the operations worker",
+		Long: `Run the operations worker as a long-lived service next to your other workers.
+Set --otlp-endpoint to export SDK metrics, workflow traces and Cloud API calls to an OpenTelemetry collector.`,
+		PersistentPreRunE: operations.StartTelemetry,
+	}
+
+	// Temporal server flags select the cluster the worker polls
+	operations.AddTemporalFlags(cmd.PersistentFlags())
+	operations.AddTelemetryFlags(cmd.PersistentFlags())
+	operations.AddAuditFlags(cmd.PersistentFlags())
+	operations.AddNotifyFlags(cmd.PersistentFlags())
+	operations.AddPolicyFlags(cmd.PersistentFlags())
+
+	// Add subcommands
+	cmd.AddCommand(newRunCommand())
+
+	return cmd
+}
+
+// newRunCommand creates the worker run subcommand
+func newRunCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run the operations worker until SIGTERM",
+		Long: `Run the operations worker until SIGINT or SIGTERM.
+On shutdown readiness turns unhealthy right away and running activities get --drain-timeout to finish.
+Workflows only write API keys and client config under --secret-dir, and to exec:// output paths with --allow-exec-sinks.
+Service accounts and API keys must satisfy the --policy file; the worker does not start without one unless --no-policy is set.
+With --no-cloud the worker starts without tcld credentials and only serves operations that never reach Temporal Cloud,
+like Nexus endpoints on the Temporal server it polls.`,
+		RunE: runWorker,
+	}
+
+	cmd.Flags().StringVarP(&taskQueue, "task-queue", "q", workers.DefaultTaskQueue, "Task queue to poll")
+	cmd.Flags().StringVar(&identity, "identity", "", "Worker identity (defaults to pid@host)")
+	cmd.Flags().IntVar(&maxConcurrentActivities, "max-concurrent-activities", 0, "Maximum concurrent activity executions (defaults to the SDK default)")
+	cmd.Flags().IntVar(&maxConcurrentWorkflowTasks, "max-concurrent-workflow-tasks", 0, "Maximum concurrent workflow task executions (defaults to the SDK default)")
+	cmd.Flags().IntVar(&maxConcurrentActivityPoller, "max-concurrent-activity-pollers", 0, "Maximum concurrent activity task pollers (defaults to the SDK default)")
+	cmd.Flags().IntVar(&maxConcurrentWorkflowPoller, "max-concurrent-workflow-pollers", 0, "Maximum concurrent workflow task pollers (defaults to the SDK default)")
+	cmd.Flags().DurationVar(&drainTimeout, "drain-timeout", 30*time.Second, "How long running activities get to finish on shutdown")
+	cmd.Flags().StringVar(&healthAddress, "health-address", ":8080", "Address serving /healthz and /readyz, empty to disable")
+	cmd.Flags().BoolVar(&allowExecSinks, "allow-exec-sinks", false, "Let workflows write API keys to exec:// output paths, which run shell commands on this worker")
+	cmd.Flags().StringArrayVar(&secretDirs, "secret-dir", nil, "Directory workflows may write API keys and client config under (repeatable, none rejects local output paths)")
+	cmd.Flags().BoolVar(&noCloud, "no-cloud", false, "Run without a Temporal Cloud client, serving only operations on the Temporal server the worker polls")
+
+	return cmd
+}
+
+// runWorker runs the operations worker with health endpoints until it is signalled to stop
+func runWorker(cmd *cobra.Command, args []string) error {
+	temporalService, err := operations.NewTemporalService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Temporal: %w", err)
+	}
+	defer func() {
+		if stopErr := temporalService.Stop(); stopErr != nil {
+			fmt.Fprintf(os.Stderr, "Error stopping TemporalService: %v\n", stopErr)
+		}
+	}()
+
+	var cloudService cloudservicev1.CloudServiceClient
+	if !noCloud {
+		fmt.Printf("🔗 Connecting to Temporal Cloud...\n")
+		client, closer, err := operations.NewCloudServiceClient()
+		if err != nil {
+			return fmt.Errorf("failed to create cloud client (pass --no-cloud to run without one): %w", err)
+		}
+		defer closer.Close()
+		cloudService = client
+	}
+
+	notifier, templates, err := operations.NewNotifier()
+	if err != nil {
+		return err
+	}
+	p, allowNone, err := operations.WorkerPolicy()
+	if err != nil {
+		return err
+	}
+	if p == nil && !allowNone {
+		return fmt.Errorf("no policy found, pass --policy, create policy.yaml in the state directory, or pass --no-policy")
+	}
+
+	operationsWorker, err := workers.NewOperationsWorker(temporalService.GetClient(), workers.OperationsWorkerOptions{
+		CloudClient:     cloudService,
+		TaskQueue:       taskQueue,
+		Notifier:        notifier,
+		NotifyTemplates: templates,
+		SecretSinks: &secrets.SinkPolicy{
+			AllowExec: allowExecSinks,
+			Dirs:      secretDirs,
+		},
+		Policy:   p,
+		NoPolicy: allowNone,
+		WorkerOptions: sdkworker.Options{
+			Identity:                               identity,
+			MaxConcurrentActivityExecutionSize:     maxConcurrentActivities,
+			MaxConcurrentWorkflowTaskExecutionSize: maxConcurrentWorkflowTasks,
+			MaxConcurrentActivityTaskPollers:       maxConcurrentActivityPoller,
+			MaxConcurrentWorkflowTaskPollers:       maxConcurrentWorkflowPoller,
+			WorkerStopTimeout:                      drainTimeout,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer operationsWorker.Stop()
+
+	var health *workers.HealthServer
+	if healthAddress != "" {
+		health = workers.NewHealthServer(healthAddress, temporalService.GetClient())
+		address, err := health.Start()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			health.Stop(ctx)
+		}()
+		fmt.Printf("🩺 Health endpoints at http://%s/healthz and /readyz\n", address)
+	}
+
+	// Readiness drops as soon as the signal arrives so no new traffic is routed while draining
+	interruptCh := make(chan interface{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		select {
+		case sig := <-signals:
+			fmt.Printf("\n🛑 Received %s, draining for up to %s...\n", sig, drainTimeout)
+		case <-cmd.Context().Done():
+		}
+		if health != nil {
+			health.SetReady(false)
+		}
+		close(interruptCh)
+	}()
+
+	fmt.Printf("👷 Operations worker polling task queue '%s' on %s\n", taskQueue, temporalService.GetFrontendHostPort())
+	if health != nil {
+		health.SetReady(true)
+	}
+	if err := operationsWorker.Run(interruptCh); err != nil {
+		return fmt.Errorf("operations worker failed: %w", err)
+	}
+	fmt.Printf("✅ Operations worker stopped\n")
+	return nil
+}
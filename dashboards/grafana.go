@@ -0,0 +1,294 @@
+package dashboards
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GrafanaDashboard is a Grafana dashboard JSON model querying Prometheus
+type GrafanaDashboard struct {
+	Title         string          `json:"title"`
+	Description   string          `json:"description,omitempty"`
+	Tags          []string        `json:"tags"`
+	Editable      bool            `json:"editable"`
+	SchemaVersion int             `json:"schemaVersion"`
+	Refresh       string          `json:"refresh"`
+	Time          GrafanaTime     `json:"time"`
+	Templating    GrafanaTemplate `json:"templating"`
+	Panels        []GrafanaPanel  `json:"panels"`
+}
+
+// GrafanaTime is the default time range
+type GrafanaTime struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GrafanaTemplate holds the dashboard variables
+type GrafanaTemplate struct {
+	List []GrafanaVariable `json:"list"`
+}
+
+// GrafanaVariable is a datasource or label_values query variable
+type GrafanaVariable struct {
+	Name       string             `json:"name"`
+	Label      string             `json:"label,omitempty"`
+	Type       string             `json:"type"`
+	Query      string             `json:"query"`
+	Datasource *GrafanaDatasource `json:"datasource,omitempty"`
+	Refresh    int                `json:"refresh,omitempty"`
+	IncludeAll bool               `json:"includeAll,omitempty"`
+	Multi      bool               `json:"multi,omitempty"`
+	AllValue   string             `json:"allValue,omitempty"`
+	Current    *GrafanaCurrent    `json:"current,omitempty"`
+}
+
+// GrafanaCurrent is a variable's selected value
+type GrafanaCurrent struct {
+	Text  string `json:"text"`
+	Value string `json:"value"`
+}
+
+// GrafanaDatasource references the Prometheus datasource
+type GrafanaDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// GrafanaPanel is a timeseries or markdown text panel
+type GrafanaPanel struct {
+	ID          int64               `json:"id"`
+	Type        string              `json:"type"`
+	Title       string              `json:"title"`
+	GridPos     GrafanaGridPos      `json:"gridPos"`
+	Datasource  *GrafanaDatasource  `json:"datasource,omitempty"`
+	Targets     []GrafanaTarget     `json:"targets,omitempty"`
+	FieldConfig *GrafanaFieldConfig `json:"fieldConfig,omitempty"`
+	Options     map[string]any      `json:"options,omitempty"`
+}
+
+// GrafanaGridPos places a panel on the 24 column grid
+type GrafanaGridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// GrafanaTarget is one PromQL query of a panel
+type GrafanaTarget struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat,omitempty"`
+}
+
+// GrafanaFieldConfig sets the panel unit
+type GrafanaFieldConfig struct {
+	Defaults struct {
+		Unit string `json:"unit,omitempty"`
+	} `json:"defaults"`
+}
+
+// prometheusLabels maps Datadog tags to Prometheus labels where they differ;
+// the OTLP to Prometheus conversion turns service.name into job
+var prometheusLabels = map[string]string{"service": "job"}
+
+// aliasPattern matches Datadog alias placeholders such as {{namespace.name}}
+var aliasPattern = regexp.MustCompile(`\{\{\s*([\w.]+?)(?:\.name)?\s*\}\}`)
+
+// percentilePattern matches Datadog percentile aggregators such as p95
+var percentilePattern = regexp.MustCompile(`^p(\d{1,2})$`)
+
+// RenderGrafana validates the template and converts it into a Grafana dashboard over Prometheus scoped by options
+func RenderGrafana(template *Dashboard, options Options) (*GrafanaDashboard, error) {
+	if err := Validate(template); err != nil {
+		return nil, err
+	}
+	title := options.Title
+	if title == "" {
+		title = template.Title
+	}
+	datasource := &GrafanaDatasource{Type: "prometheus", UID: options.Datasource}
+	if datasource.UID == "" {
+		datasource.UID = "${datasource}"
+	}
+
+	dashboard := &GrafanaDashboard{
+		Title:         title,
+		Description:   template.Description,
+		Tags:          []string{"temporal"},
+		Editable:      true,
+		SchemaVersion: 39,
+		Refresh:       "30s",
+		Time:          GrafanaTime{From: "now-1h", To: "now"},
+	}
+	if options.Datasource == "" {
+		dashboard.Templating.List = append(dashboard.Templating.List, GrafanaVariable{
+			Name:  "datasource",
+			Label: "Datasource",
+			Type:  "datasource",
+			Query: "prometheus",
+		})
+	}
+	for _, tag := range filterTags {
+		current := &GrafanaCurrent{Text: "All", Value: "$__all"}
+		if value := options.filterDefault(tag); value != "*" {
+			current = &GrafanaCurrent{Text: value, Value: value}
+		}
+		dashboard.Templating.List = append(dashboard.Templating.List, GrafanaVariable{
+			Name:       tag,
+			Type:       "query",
+			Query:      fmt.Sprintf("label_values(%s)", prometheusLabel(tag)),
+			Datasource: datasource,
+			Refresh:    2,
+			IncludeAll: true,
+			Multi:      true,
+			AllValue:   ".*",
+			Current:    current,
+		})
+	}
+
+	for _, widget := range template.Widgets {
+		layout := widget.Layout
+		panel := GrafanaPanel{
+			ID:      widget.ID,
+			Title:   widget.Definition.Title,
+			GridPos: GrafanaGridPos{X: layout.X * 2, Y: layout.Y * 3, W: layout.Width * 2, H: layout.Height * 3},
+		}
+		switch widget.Definition.Type {
+		case "note":
+			panel.Type = "text"
+			panel.Options = map[string]any{"mode": "markdown", "content": widget.Definition.Content}
+		case "timeseries":
+			panel.Type = "timeseries"
+			panel.Datasource = datasource
+			panel.FieldConfig = &GrafanaFieldConfig{}
+			for i, request := range widget.Definition.Requests {
+				query, err := ParseQuery(request.Query)
+				if err != nil {
+					return nil, err
+				}
+				kind, err := metricKind(query)
+				if err != nil {
+					return nil, err
+				}
+				expr, unit, err := promQL(scopeQuery(query, options), kind, options.HistogramSuffix)
+				if err != nil {
+					return nil, fmt.Errorf("%q: %w", widget.Definition.Title, err)
+				}
+				target := GrafanaTarget{RefID: string(rune('A' + i)), Expr: expr}
+				for _, metadata := range request.Metadata {
+					if metadata.Expression == request.Query {
+						target.LegendFormat = aliasPattern.ReplaceAllStringFunc(metadata.AliasName, func(placeholder string) string {
+							return "{{" + prometheusLabel(aliasPattern.FindStringSubmatch(placeholder)[1]) + "}}"
+						})
+					}
+				}
+				panel.Targets = append(panel.Targets, target)
+				panel.FieldConfig.Defaults.Unit = unit
+			}
+		default:
+			return nil, fmt.Errorf("widget %q: unsupported widget type %s", widget.Definition.Title, widget.Definition.Type)
+		}
+		dashboard.Panels = append(dashboard.Panels, panel)
+	}
+	return dashboard, nil
+}
+
+// promQL converts a scoped Datadog query into PromQL and the panel unit
+func promQL(query *Query, kind MetricKind, histogramSuffix string) (string, string, error) {
+	name, suffix := query.BaseMetric()
+	name = strings.ReplaceAll(name, ".", "_")
+	selector := promSelector(query.Filters)
+	by := promLabels(query.GroupBy)
+
+	var rate bool
+	switch query.Functions {
+	case "":
+	case ".as_rate()":
+		rate = true
+	default:
+		return "", "", fmt.Errorf("unsupported functions %s", query.Functions)
+	}
+
+	if kind == Histogram {
+		if suffix == "" {
+			match := percentilePattern.FindStringSubmatch(query.Aggregator)
+			if match == nil {
+				return "", "", fmt.Errorf("histogram %s needs a percentile aggregator, got %q", name, query.Aggregator)
+			}
+			percentile, _ := strconv.Atoi(match[1])
+			return fmt.Sprintf("histogram_quantile(%g, sum by (%s) (rate(%s%s_bucket%s[$__rate_interval])))",
+				float64(percentile)/100, strings.Join(append([]string{"le"}, by...), ", "), name, histogramSuffix, selector), "s", nil
+		}
+		if suffix != ".count" && suffix != ".sum" {
+			return "", "", fmt.Errorf("unsupported histogram sub-metric %s", suffix)
+		}
+		name += histogramSuffix + "_" + strings.TrimPrefix(suffix, ".")
+		kind = Counter
+	}
+
+	aggregator := query.Aggregator
+	switch aggregator {
+	case "":
+		aggregator = "avg"
+		if kind == Counter {
+			aggregator = "sum"
+		}
+	case "sum", "avg", "min", "max":
+	default:
+		return "", "", fmt.Errorf("unsupported aggregator %s for %s", aggregator, name)
+	}
+	aggregation := aggregator
+	if len(by) > 0 {
+		aggregation += " by (" + strings.Join(by, ", ") + ")"
+	}
+
+	if kind == Gauge {
+		return fmt.Sprintf("%s (%s%s)", aggregation, name, selector), "short", nil
+	}
+	if rate {
+		return fmt.Sprintf("%s (rate(%s%s[$__rate_interval]))", aggregation, name, selector), "ops", nil
+	}
+	return fmt.Sprintf("%s (increase(%s%s[$__rate_interval]))", aggregation, name, selector), "short", nil
+}
+
+// promSelector converts Datadog tag filters into a Prometheus label selector
+func promSelector(filters []string) string {
+	var matchers []string
+	for _, filter := range filters {
+		negated := strings.HasPrefix(filter, "!")
+		tag, value := splitFilter(strings.TrimPrefix(filter, "!"))
+		label := prometheusLabel(tag)
+		switch {
+		case value == "*":
+		case strings.HasPrefix(value, "$"):
+			matchers = append(matchers, fmt.Sprintf("%s=~%q", label, value))
+		case negated:
+			matchers = append(matchers, fmt.Sprintf("%s!=%q", label, value))
+		default:
+			matchers = append(matchers, fmt.Sprintf("%s=%q", label, value))
+		}
+	}
+	if len(matchers) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(matchers, ", ") + "}"
+}
+
+func promLabels(tags []string) []string {
+	labels := make([]string, len(tags))
+	for i, tag := range tags {
+		labels[i] = prometheusLabel(tag)
+	}
+	return labels
+}
+
+func prometheusLabel(tag string) string {
+	if label, ok := prometheusLabels[tag]; ok {
+		return label
+	}
+	return tag
+}
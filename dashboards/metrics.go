@@ -0,0 +1,137 @@
+package dashboards
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SDKMetricPrefix is the prefix of every metric the Go SDK emits and the one templates are written with
+const SDKMetricPrefix = "temporal_"
+
+// MetricKind is how a metric is recorded by the SDK metrics handler
+type MetricKind string
+
+const (
+	Counter   MetricKind = "counter"
+	Gauge     MetricKind = "gauge"
+	Histogram MetricKind = "histogram"
+)
+
+// SDKMetrics are the metrics the Go SDK emits, from go.temporal.io/sdk/internal/common/metrics.
+// Timers are histograms recorded in seconds.
+var SDKMetrics = map[string]MetricKind{
+	"temporal_workflow_completed":        Counter,
+	"temporal_workflow_canceled":         Counter,
+	"temporal_workflow_failed":           Counter,
+	"temporal_workflow_continue_as_new":  Counter,
+	"temporal_workflow_endtoend_latency": Histogram,
+
+	"temporal_workflow_task_replay_latency":            Histogram,
+	"temporal_workflow_task_queue_poll_empty":          Counter,
+	"temporal_workflow_task_queue_poll_succeed":        Counter,
+	"temporal_workflow_task_schedule_to_start_latency": Histogram,
+	"temporal_workflow_task_execution_latency":         Histogram,
+	"temporal_workflow_task_execution_failed":          Counter,
+	"temporal_workflow_task_no_completion":             Counter,
+
+	"temporal_activity_poll_no_task":              Counter,
+	"temporal_activity_schedule_to_start_latency": Histogram,
+	"temporal_activity_execution_failed":          Counter,
+	"temporal_unregistered_activity_invocation":   Counter,
+	"temporal_activity_execution_latency":         Histogram,
+	"temporal_activity_succeed_endtoend_latency":  Histogram,
+	"temporal_activity_task_error":                Counter,
+
+	"temporal_local_activity_total":                    Counter,
+	"temporal_local_activity_canceled":                 Counter,
+	"temporal_local_activity_execution_cancelled":      Counter,
+	"temporal_local_activity_failed":                   Counter,
+	"temporal_local_activity_execution_failed":         Counter,
+	"temporal_local_activity_error":                    Counter,
+	"temporal_local_activity_execution_latency":        Histogram,
+	"temporal_local_activity_succeed_endtoend_latency": Histogram,
+
+	"temporal_corrupted_signals": Counter,
+
+	"temporal_worker_start":                Counter,
+	"temporal_worker_task_slots_available": Gauge,
+	"temporal_worker_task_slots_used":      Gauge,
+	"temporal_poller_start":                Counter,
+	"temporal_num_pollers":                 Gauge,
+
+	"temporal_request":                         Counter,
+	"temporal_request_failure":                 Counter,
+	"temporal_request_latency":                 Histogram,
+	"temporal_long_request":                    Counter,
+	"temporal_long_request_failure":            Counter,
+	"temporal_long_request_latency":            Histogram,
+	"temporal_request_resource_exhausted":      Counter,
+	"temporal_long_request_resource_exhausted": Counter,
+
+	"temporal_sticky_cache_hit":                   Counter,
+	"temporal_sticky_cache_miss":                  Counter,
+	"temporal_sticky_cache_total_forced_eviction": Counter,
+	"temporal_sticky_cache_size":                  Gauge,
+
+	"temporal_workflow_active_thread_count": Gauge,
+
+	"temporal_nexus_poll_no_task":                   Counter,
+	"temporal_nexus_task_schedule_to_start_latency": Histogram,
+	"temporal_nexus_task_execution_failed":          Counter,
+	"temporal_nexus_task_execution_latency":         Histogram,
+	"temporal_nexus_task_endtoend_latency":          Histogram,
+}
+
+// Validate checks every query of the template parses and references a metric the Go SDK emits
+func Validate(dashboard *Dashboard) error {
+	var problems []string
+	for _, widget := range dashboard.Widgets {
+		for _, request := range widget.Definition.Requests {
+			query, err := ParseQuery(request.Query)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%q: %v", widget.Definition.Title, err))
+				continue
+			}
+			if _, err := metricKind(query); err != nil {
+				problems = append(problems, fmt.Sprintf("%q: %v", widget.Definition.Title, err))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid dashboard template:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// MetricNames returns the SDK metrics a template references, sorted
+func MetricNames(dashboard *Dashboard) []string {
+	seen := map[string]bool{}
+	for _, widget := range dashboard.Widgets {
+		for _, request := range widget.Definition.Requests {
+			if query, err := ParseQuery(request.Query); err == nil {
+				name, _ := query.BaseMetric()
+				seen[name] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// metricKind looks up the template query's metric, distribution suffixes are only valid on histograms
+func metricKind(query *Query) (MetricKind, error) {
+	name, suffix := query.BaseMetric()
+	kind, ok := SDKMetrics[name]
+	if !ok {
+		return "", fmt.Errorf("metric %s is not emitted by the Go SDK", query.Metric)
+	}
+	if suffix != "" && kind != Histogram {
+		return "", fmt.Errorf("metric %s: %s is only valid on histograms, %s is a %s", query.Metric, suffix, name, kind)
+	}
+	return kind, nil
+}
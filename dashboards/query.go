@@ -0,0 +1,88 @@
+package dashboards
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// queryPattern matches the Datadog metric queries used by the templates,
+// e.g. sum:temporal_request{namespace:$namespace} by {namespace,operation}.as_rate()
+var queryPattern = regexp.MustCompile(`^(?:(\w+):)?([\w.]+)\{([^}]*)\}(?: by \{([^}]*)\})?((?:\.\w+\(\))*)$`)
+
+// Query is a parsed Datadog metric query
+type Query struct {
+	// Aggregator is the space aggregation, e.g. sum or p95 (empty for the default avg)
+	Aggregator string
+	// Metric is the metric name including any Datadog suffix such as .count
+	Metric string
+	// Filters are the tag:value scope items
+	Filters []string
+	// GroupBy are the tags the series are split by
+	GroupBy []string
+	// Functions are the trailing functions, e.g. .as_rate()
+	Functions string
+}
+
+// ParseQuery parses a Datadog metric query
+func ParseQuery(q string) (*Query, error) {
+	match := queryPattern.FindStringSubmatch(strings.TrimSpace(q))
+	if match == nil {
+		return nil, fmt.Errorf("unsupported query %q", q)
+	}
+	return &Query{
+		Aggregator: match[1],
+		Metric:     match[2],
+		Filters:    splitList(match[3]),
+		GroupBy:    splitList(match[4]),
+		Functions:  match[5],
+	}, nil
+}
+
+// String formats the query back into Datadog syntax
+func (q *Query) String() string {
+	var b strings.Builder
+	if q.Aggregator != "" {
+		b.WriteString(q.Aggregator + ":")
+	}
+	b.WriteString(q.Metric)
+	scope := "*"
+	if len(q.Filters) > 0 {
+		scope = strings.Join(q.Filters, ",")
+	}
+	b.WriteString("{" + scope + "}")
+	if len(q.GroupBy) > 0 {
+		b.WriteString(" by {" + strings.Join(q.GroupBy, ",") + "}")
+	}
+	b.WriteString(q.Functions)
+	return b.String()
+}
+
+// BaseMetric returns the metric name without a Datadog distribution suffix such as .count
+func (q *Query) BaseMetric() (name, suffix string) {
+	for _, s := range distributionSuffixes {
+		if strings.HasSuffix(q.Metric, s) {
+			return strings.TrimSuffix(q.Metric, s), s
+		}
+	}
+	return q.Metric, ""
+}
+
+// distributionSuffixes are the Datadog sub-metrics of a histogram
+var distributionSuffixes = []string{".count", ".sum", ".avg", ".min", ".max"}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" && item != "*" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// splitFilter splits a tag:value filter, values may contain colons
+func splitFilter(filter string) (tag, value string) {
+	tag, value, _ = strings.Cut(filter, ":")
+	return tag, value
+}
@@ -0,0 +1,144 @@
+package dashboards
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Formats a dashboard can be rendered in
+const (
+	FormatDatadog = "datadog"
+	FormatGrafana = "grafana"
+)
+
+// Formats are the supported output formats
+var Formats = []string{FormatDatadog, FormatGrafana}
+
+// filterTags are the tags every query is scoped by, each backed by a template variable of the same name
+var filterTags = []string{"service", "namespace", "task_queue"}
+
+// Options parameterizes a rendered dashboard
+type Options struct {
+	// Title replaces the template title (optional)
+	Title string
+	// Service, Namespace and TaskQueue are the default filter values (optional, defaults to all)
+	Service   string
+	Namespace string
+	TaskQueue string
+	// MetricPrefix replaces the SDK's temporal_ prefix, e.g. when the pipeline adds a namespace (optional)
+	MetricPrefix string
+	// Datasource is the Grafana Prometheus datasource uid (optional, defaults to a datasource variable)
+	Datasource string
+	// HistogramSuffix is appended to latency histograms in Prometheus, the OTLP conversion adds _seconds (optional)
+	HistogramSuffix string
+}
+
+// filterDefault returns the default value of a filter tag
+func (o *Options) filterDefault(tag string) string {
+	value := map[string]string{"service": o.Service, "namespace": o.Namespace, "task_queue": o.TaskQueue}[tag]
+	if value == "" {
+		return "*"
+	}
+	return value
+}
+
+// metricName swaps the SDK prefix for the configured one
+func (o *Options) metricName(name string) string {
+	if o.MetricPrefix == "" {
+		return name
+	}
+	return o.MetricPrefix + strings.TrimPrefix(name, SDKMetricPrefix)
+}
+
+// RenderDatadog validates the template and returns a Datadog dashboard scoped by options
+func RenderDatadog(template *Dashboard, options Options) (*Dashboard, error) {
+	if err := Validate(template); err != nil {
+		return nil, err
+	}
+	dashboard, err := clone(template)
+	if err != nil {
+		return nil, err
+	}
+	if options.Title != "" {
+		dashboard.Title = options.Title
+	}
+	dashboard.TemplateVariables = templateVariables(dashboard.TemplateVariables, options)
+
+	for i := range dashboard.Widgets {
+		requests := dashboard.Widgets[i].Definition.Requests
+		for j := range requests {
+			query, err := ParseQuery(requests[j].Query)
+			if err != nil {
+				return nil, err
+			}
+			rendered := scopeQuery(query, options).String()
+			for k := range requests[j].Metadata {
+				if requests[j].Metadata[k].Expression == requests[j].Query {
+					requests[j].Metadata[k].Expression = rendered
+				}
+			}
+			requests[j].Query = rendered
+		}
+	}
+	return dashboard, nil
+}
+
+// scopeQuery applies the metric prefix and replaces the filter tags with their template variables
+func scopeQuery(query *Query, options Options) *Query {
+	scoped := *query
+	name, suffix := query.BaseMetric()
+	scoped.Metric = options.metricName(name) + suffix
+
+	scoped.Filters = nil
+	for _, tag := range filterTags {
+		scoped.Filters = append(scoped.Filters, tag+":$"+tag)
+	}
+	for _, filter := range query.Filters {
+		if tag, _ := splitFilter(strings.TrimPrefix(filter, "!")); !contains(filterTags, tag) {
+			scoped.Filters = append(scoped.Filters, filter)
+		}
+	}
+	return &scoped
+}
+
+// templateVariables sets a variable for each filter tag, keeping any other variables of the template
+func templateVariables(variables []TemplateVariable, options Options) []TemplateVariable {
+	result := make([]TemplateVariable, 0, len(variables)+len(filterTags))
+	for _, tag := range filterTags {
+		result = append(result, TemplateVariable{
+			Name:            tag,
+			Prefix:          tag,
+			AvailableValues: []string{},
+			Default:         options.filterDefault(tag),
+		})
+	}
+	for _, variable := range variables {
+		if !contains(filterTags, variable.Name) {
+			result = append(result, variable)
+		}
+	}
+	return result
+}
+
+// clone deep copies a dashboard
+func clone(dashboard *Dashboard) (*Dashboard, error) {
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy dashboard: %w", err)
+	}
+	var copied Dashboard
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, fmt.Errorf("failed to copy dashboard: %w", err)
+	}
+	return &copied, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
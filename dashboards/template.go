@@ -0,0 +1,114 @@
+package dashboards
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// defaultTemplate is the bundled Datadog dashboard for the Temporal SDK metrics
+//
+//go:embed datadog-core-sdk-otel.json
+var defaultTemplate []byte
+
+// Dashboard is a Datadog dashboard definition, the format templates are written in
+type Dashboard struct {
+	Title             string             `json:"title"`
+	Description       string             `json:"description"`
+	TemplateVariables []TemplateVariable `json:"template_variables"`
+	Widgets           []Widget           `json:"widgets"`
+	LayoutType        string             `json:"layout_type"`
+	IsReadOnly        bool               `json:"is_read_only"`
+	NotifyList        []string           `json:"notify_list"`
+	ReflowType        string             `json:"reflow_type,omitempty"`
+}
+
+// TemplateVariable is a dashboard-wide tag filter referenced in queries as $name
+type TemplateVariable struct {
+	Name            string   `json:"name"`
+	Prefix          string   `json:"prefix"`
+	AvailableValues []string `json:"available_values"`
+	Default         string   `json:"default"`
+}
+
+// Widget is a note or a chart placed on the dashboard grid
+type Widget struct {
+	ID         int64            `json:"id"`
+	Definition WidgetDefinition `json:"definition"`
+	Layout     WidgetLayout     `json:"layout"`
+}
+
+// WidgetDefinition holds the fields of the note and timeseries widgets used by the templates
+type WidgetDefinition struct {
+	Type            string          `json:"type"`
+	Title           string          `json:"title,omitempty"`
+	TitleSize       string          `json:"title_size,omitempty"`
+	TitleAlign      string          `json:"title_align,omitempty"`
+	Content         string          `json:"content,omitempty"`
+	BackgroundColor string          `json:"background_color,omitempty"`
+	FontSize        string          `json:"font_size,omitempty"`
+	TextAlign       string          `json:"text_align,omitempty"`
+	ShowTick        *bool           `json:"show_tick,omitempty"`
+	TickPos         string          `json:"tick_pos,omitempty"`
+	TickEdge        string          `json:"tick_edge,omitempty"`
+	Requests        []WidgetRequest `json:"requests,omitempty"`
+	ShowLegend      *bool           `json:"show_legend,omitempty"`
+	LegendColumns   []string        `json:"legend_columns,omitempty"`
+	LegendLayout    string          `json:"legend_layout,omitempty"`
+	YAxis           *YAxis          `json:"yaxis,omitempty"`
+}
+
+// WidgetRequest is one query drawn on a chart
+type WidgetRequest struct {
+	Query       string            `json:"q"`
+	DisplayType string            `json:"display_type,omitempty"`
+	Style       *RequestStyle     `json:"style,omitempty"`
+	Metadata    []RequestMetadata `json:"metadata,omitempty"`
+}
+
+// RequestStyle is how a query is drawn
+type RequestStyle struct {
+	Palette   string `json:"palette,omitempty"`
+	LineType  string `json:"line_type,omitempty"`
+	LineWidth string `json:"line_width,omitempty"`
+}
+
+// RequestMetadata aliases the series of a query expression in the legend
+type RequestMetadata struct {
+	Expression string `json:"expression"`
+	AliasName  string `json:"alias_name,omitempty"`
+}
+
+// YAxis configures a chart's y axis
+type YAxis struct {
+	Scale       string `json:"scale,omitempty"`
+	Label       string `json:"label"`
+	IncludeZero bool   `json:"include_zero"`
+	Min         string `json:"min,omitempty"`
+	Max         string `json:"max,omitempty"`
+}
+
+// WidgetLayout places a widget on the 12 column grid
+type WidgetLayout struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// LoadTemplate reads a Datadog dashboard template, or the bundled one when path is empty
+func LoadTemplate(path string) (*Dashboard, error) {
+	data := defaultTemplate
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read dashboard template: %w", err)
+		}
+	}
+	var dashboard Dashboard
+	if err := json.Unmarshal(data, &dashboard); err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard template: %w", err)
+	}
+	return &dashboard, nil
+}
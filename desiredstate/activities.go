@@ -0,0 +1,105 @@
+package desiredstate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"temporal-jumpstart-operations/workflows/activities"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/sdk/temporal"
+)
+
+var TypeActivities *Activities
+
+type DetectDriftRequest struct {
+	// DesiredStatePath is the desired state file the Cloud identities are compared against
+	DesiredStatePath string `json:"desiredStatePath"`
+	// ReportPath is the directory the drift report artifact is written to
+	ReportPath string `json:"reportPath"`
+	// MaxKeyLifetime is the longest lifetime an api key may have, e.g. '90d' (optional)
+	MaxKeyLifetime string `json:"maxKeyLifetime"`
+}
+type DetectDriftResponse struct {
+	// ReportFile is the drift report artifact written by the activity
+	ReportFile string `json:"reportFile"`
+	// Findings is the total number of findings in the report
+	Findings int `json:"findings"`
+	// Summary is a one line count of findings per category
+	Summary string `json:"summary"`
+}
+
+// Activities contains the activities that need the desired state, kept apart from
+// the workflows/activities package which this package builds on
+type Activities struct {
+	// CloudClient is the Temporal Cloud service client for making API calls
+	CloudClient cloudservicev1.CloudServiceClient
+}
+
+// NewActivities creates a new Activities instance with the provided cloud client
+func NewActivities(cloudClient cloudservicev1.CloudServiceClient) *Activities {
+	return &Activities{
+		CloudClient: cloudClient,
+	}
+}
+
+// DetectDrift compares Cloud identities against the desired state file and writes the drift report
+// as JSON to {ReportPath}/drift-report-{timestamp}.json. The report itself stays out of workflow history.
+func (a *Activities) DetectDrift(ctx context.Context, args *DetectDriftRequest) (*DetectDriftResponse, error) {
+	desired, err := Load(args.DesiredStatePath)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+
+	var maxKeyLifetime time.Duration
+	if args.MaxKeyLifetime != "" {
+		if maxKeyLifetime, err = activities.ParseDuration(args.MaxKeyLifetime); err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+		}
+	}
+
+	observed, err := Observe(ctx, a.CloudClient)
+	if err != nil {
+		return nil, err
+	}
+
+	report := DetectDrift(desired, observed, maxKeyLifetime, time.Now().UTC())
+	reportFile, err := WriteDriftReport(report, args.ReportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DetectDriftResponse{
+		ReportFile: reportFile,
+		Findings:   report.FindingCount(),
+		Summary:    report.Summary(),
+	}, nil
+}
+
+// WriteDriftReport writes the report as indented JSON into dir and returns the file name
+func WriteDriftReport(report *DriftReport, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create report path: %w", err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode drift report: %w", err)
+	}
+	reportFile := filepath.Join(dir, fmt.Sprintf("drift-report-%s.json", report.GeneratedAt.Format("20060102T150405Z")))
+	if err := os.WriteFile(reportFile, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write drift report: %w", err)
+	}
+	return reportFile, nil
+}
+
+// Summary returns a one line count of findings per category
+func (r *DriftReport) Summary() string {
+	return fmt.Sprintf("unmanaged accounts=%d unmanaged keys=%d missing accounts=%d missing keys=%d role escalations=%d keys past lifetime=%d",
+		len(r.UnmanagedServiceAccounts), len(r.UnmanagedApiKeys),
+		len(r.MissingServiceAccounts), len(r.MissingApiKeys),
+		len(r.RoleEscalations), len(r.KeysPastLifetime))
+}
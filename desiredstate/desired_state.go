@@ -0,0 +1,180 @@
+package desiredstate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"temporal-jumpstart-operations/ownership"
+	"temporal-jumpstart-operations/policy"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DesiredState is the declarative description of the identities jumpstart manages in a Cloud account
+type DesiredState struct {
+	// ServiceAccounts are the service accounts that should exist, matched to Cloud by name
+	ServiceAccounts []ServiceAccount `yaml:"serviceAccounts" json:"serviceAccounts"`
+}
+
+// ServiceAccount declares a service account, its access and the API keys it should own
+type ServiceAccount struct {
+	// Name is the service account name (required, unique)
+	Name string `yaml:"name" json:"name"`
+	// Description is the service account description (optional)
+	Description string `yaml:"description" json:"description"`
+	// DescriptionDefaulted is set when Description was not given and carries the default
+	DescriptionDefaulted bool `yaml:"-" json:"descriptionDefaulted,omitempty"`
+	// Owner is recorded in an owner tag appended to the description (optional)
+	Owner *ownership.Owner `yaml:"owner" json:"owner,omitempty"`
+	// AccountRole is the account level role (optional, defaults to read)
+	AccountRole string `yaml:"accountRole" json:"accountRole"`
+	// Namespaces maps a namespace id to the permission granted on it (admin, write, read)
+	Namespaces map[string]string `yaml:"namespaces" json:"namespaces"`
+	// ApiKeys are the API keys the service account should own
+	ApiKeys []ApiKey `yaml:"apiKeys" json:"apiKeys"`
+}
+
+// ApiKey declares an API key owned by a service account
+type ApiKey struct {
+	// Name is the API key display name (required, globally unique)
+	Name string `yaml:"name" json:"name"`
+	// Description is the API key description (optional)
+	Description string `yaml:"description" json:"description"`
+	// DescriptionDefaulted is set when Description was not given and carries the default
+	DescriptionDefaulted bool `yaml:"-" json:"descriptionDefaulted,omitempty"`
+	// Duration is the lifetime of the key when it is created (optional, defaults to '1y')
+	Duration string `yaml:"duration" json:"duration"`
+	// OutputPath is where the key token is written when it is created (required)
+	OutputPath string `yaml:"outputPath" json:"outputPath"`
+}
+
+// Access returns the activities representation of the service account's declared access
+func (sa *ServiceAccount) Access() *activities.Access {
+	return &activities.Access{
+		AccountRole:          sa.AccountRole,
+		NamespacePermissions: sa.Namespaces,
+	}
+}
+
+// Load reads and validates a desired state file
+func Load(path string) (*DesiredState, error) {
+	return LoadWithPolicy(path, nil)
+}
+
+// LoadWithPolicy reads a desired state file, checks it against a policy before defaults are filled in, and validates it.
+// Policy violations are returned as policy.Violations.
+func LoadWithPolicy(path string, p *policy.Policy) (*DesiredState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read desired state file: %w", err)
+	}
+
+	var state DesiredState
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&state); err != nil {
+		return nil, fmt.Errorf("failed to parse desired state file %s: %w", path, err)
+	}
+
+	if err := state.CheckPolicy(p).Err(); err != nil {
+		return nil, err
+	}
+	state.applyDefaults()
+	if err := state.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid desired state file %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// CheckPolicy evaluates every declared service account and API key against a policy
+func (s *DesiredState) CheckPolicy(p *policy.Policy) policy.Violations {
+	var violations policy.Violations
+	for _, sa := range s.ServiceAccounts {
+		role := sa.AccountRole
+		if role == "" {
+			role = "read"
+		}
+		violations = append(violations, p.CheckServiceAccount(policy.ServiceAccount{
+			Name:        sa.Name,
+			Description: sa.Description,
+			AccountRole: role,
+		})...)
+		for _, key := range sa.ApiKeys {
+			keyDuration := key.Duration
+			if keyDuration == "" {
+				keyDuration = "1y"
+			}
+			violations = append(violations, p.CheckApiKey(policy.ApiKey{
+				ServiceAccountName: sa.Name,
+				Name:               key.Name,
+				Description:        key.Description,
+				Duration:           keyDuration,
+			})...)
+		}
+	}
+	return violations
+}
+
+// applyDefaults fills in the same defaults the create command uses
+func (s *DesiredState) applyDefaults() {
+	for i := range s.ServiceAccounts {
+		sa := &s.ServiceAccounts[i]
+		if sa.Description == "" {
+			sa.Description = fmt.Sprintf("Service account for temporal jumpstart operations - %s", sa.Name)
+			sa.DescriptionDefaulted = true
+		}
+		sa.Description = ownership.EncodeDescription(sa.Description, sa.Owner)
+		for j := range sa.ApiKeys {
+			key := &sa.ApiKeys[j]
+			if key.Description == "" {
+				key.Description = fmt.Sprintf("API key for service account %s", sa.Name)
+				key.DescriptionDefaulted = true
+			}
+			if key.Duration == "" {
+				key.Duration = "1y"
+			}
+		}
+	}
+}
+
+// Validate checks required fields, uniqueness of names, roles, permissions and durations
+func (s *DesiredState) Validate() error {
+	accountNames := map[string]bool{}
+	keyNames := map[string]bool{}
+	for _, sa := range s.ServiceAccounts {
+		if sa.Name == "" {
+			return fmt.Errorf("service account name is required")
+		}
+		if accountNames[strings.ToLower(sa.Name)] {
+			return fmt.Errorf("service account %s is declared more than once", sa.Name)
+		}
+		accountNames[strings.ToLower(sa.Name)] = true
+
+		if _, err := sa.Access().ToIdentityAccess(); err != nil {
+			return fmt.Errorf("service account %s: %w", sa.Name, err)
+		}
+		if err := sa.Owner.Validate(); err != nil {
+			return fmt.Errorf("service account %s: %w", sa.Name, err)
+		}
+
+		for _, key := range sa.ApiKeys {
+			if key.Name == "" {
+				return fmt.Errorf("service account %s: api key name is required", sa.Name)
+			}
+			if keyNames[strings.ToLower(key.Name)] {
+				return fmt.Errorf("api key %s is declared more than once", key.Name)
+			}
+			keyNames[strings.ToLower(key.Name)] = true
+			if key.OutputPath == "" {
+				return fmt.Errorf("api key %s: outputPath is required", key.Name)
+			}
+			if _, err := activities.ParseDuration(key.Duration); err != nil {
+				return fmt.Errorf("api key %s: %w", key.Name, err)
+			}
+		}
+	}
+	return nil
+}
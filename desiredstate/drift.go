@@ -0,0 +1,217 @@
+package desiredstate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"temporal-jumpstart-operations/workflows/activities"
+
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+)
+
+// DriftReport lists every difference between Temporal Cloud identities and the desired state
+type DriftReport struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	// MaxKeyLifetime is the key lifetime policy the report was evaluated against
+	MaxKeyLifetime string `json:"maxKeyLifetime"`
+	// UnmanagedServiceAccounts exist in Cloud but are not declared
+	UnmanagedServiceAccounts []string `json:"unmanagedServiceAccounts"`
+	// UnmanagedApiKeys are owned by a declared service account but are not declared themselves
+	UnmanagedApiKeys []string `json:"unmanagedApiKeys"`
+	// MissingServiceAccounts are declared but do not exist in Cloud
+	MissingServiceAccounts []string `json:"missingServiceAccounts"`
+	// MissingApiKeys are declared but do not exist in Cloud
+	MissingApiKeys []string `json:"missingApiKeys"`
+	// RoleEscalations are service accounts holding more access in Cloud than declared
+	RoleEscalations []RoleEscalation `json:"roleEscalations"`
+	// KeysPastLifetime are api keys whose age or lifetime exceeds MaxKeyLifetime
+	KeysPastLifetime []KeyLifetimeViolation `json:"keysPastLifetime"`
+}
+
+// RoleEscalation describes access granted in Cloud beyond what is declared
+type RoleEscalation struct {
+	ServiceAccount string `json:"serviceAccount"`
+	Declared       string `json:"declared"`
+	Actual         string `json:"actual"`
+	// Details lists each role or namespace permission that exceeds the declaration
+	Details []string `json:"details"`
+}
+
+// KeyLifetimeViolation describes an api key that outlives the key lifetime policy
+type KeyLifetimeViolation struct {
+	ApiKey      string    `json:"apiKey"`
+	ApiKeyId    string    `json:"apiKeyId"`
+	OwnerId     string    `json:"ownerId"`
+	CreatedTime time.Time `json:"createdTime"`
+	ExpiryTime  time.Time `json:"expiryTime"`
+	Reason      string    `json:"reason"`
+}
+
+// accountRoleRank orders account roles by the access they grant
+var accountRoleRank = map[identityv1.AccountAccess_Role]int{
+	identityv1.AccountAccess_ROLE_UNSPECIFIED:   0,
+	identityv1.AccountAccess_ROLE_READ:          1,
+	identityv1.AccountAccess_ROLE_FINANCE_ADMIN: 2,
+	identityv1.AccountAccess_ROLE_DEVELOPER:     3,
+	identityv1.AccountAccess_ROLE_ADMIN:         4,
+	identityv1.AccountAccess_ROLE_OWNER:         5,
+}
+
+// namespacePermissionRank orders namespace permissions by the access they grant
+var namespacePermissionRank = map[identityv1.NamespaceAccess_Permission]int{
+	identityv1.NamespaceAccess_PERMISSION_UNSPECIFIED: 0,
+	identityv1.NamespaceAccess_PERMISSION_READ:        1,
+	identityv1.NamespaceAccess_PERMISSION_WRITE:       2,
+	identityv1.NamespaceAccess_PERMISSION_ADMIN:       3,
+}
+
+// HasDrift reports whether the report contains any finding
+func (r *DriftReport) HasDrift() bool {
+	return r.FindingCount() > 0
+}
+
+// FindingCount returns the total number of findings in the report
+func (r *DriftReport) FindingCount() int {
+	return len(r.UnmanagedServiceAccounts) + len(r.UnmanagedApiKeys) +
+		len(r.MissingServiceAccounts) + len(r.MissingApiKeys) +
+		len(r.RoleEscalations) + len(r.KeysPastLifetime)
+}
+
+// DetectDrift compares the observed Cloud identities against the desired state.
+// Keys whose lifetime or age exceeds maxKeyLifetime are reported; a zero maxKeyLifetime disables the check.
+func DetectDrift(desired *DesiredState, observed *Observed, maxKeyLifetime time.Duration, now time.Time) *DriftReport {
+	report := &DriftReport{GeneratedAt: now}
+	if maxKeyLifetime > 0 {
+		report.MaxKeyLifetime = maxKeyLifetime.String()
+	}
+
+	accountsByName := map[string]*identityv1.ServiceAccount{}
+	accountsById := map[string]*identityv1.ServiceAccount{}
+	for _, sa := range observed.ServiceAccounts {
+		accountsByName[strings.ToLower(sa.GetSpec().GetName())] = sa
+		accountsById[sa.Id] = sa
+	}
+	keysByName := map[string]*identityv1.ApiKey{}
+	for _, key := range observed.ApiKeys {
+		keysByName[strings.ToLower(key.GetSpec().GetDisplayName())] = key
+	}
+
+	declaredAccounts := map[string]bool{}
+	declaredKeys := map[string]bool{}
+	for _, sa := range desired.ServiceAccounts {
+		declaredAccounts[strings.ToLower(sa.Name)] = true
+		for _, key := range sa.ApiKeys {
+			declaredKeys[strings.ToLower(key.Name)] = true
+			if _, ok := keysByName[strings.ToLower(key.Name)]; !ok {
+				report.MissingApiKeys = append(report.MissingApiKeys, fmt.Sprintf("%s/%s", sa.Name, key.Name))
+			}
+		}
+
+		current, ok := accountsByName[strings.ToLower(sa.Name)]
+		if !ok {
+			report.MissingServiceAccounts = append(report.MissingServiceAccounts, sa.Name)
+			continue
+		}
+		if escalation := detectEscalation(sa, current); escalation != nil {
+			report.RoleEscalations = append(report.RoleEscalations, *escalation)
+		}
+	}
+
+	for _, sa := range observed.ServiceAccounts {
+		if !declaredAccounts[strings.ToLower(sa.GetSpec().GetName())] {
+			report.UnmanagedServiceAccounts = append(report.UnmanagedServiceAccounts, sa.GetSpec().GetName())
+		}
+	}
+
+	for _, key := range observed.ApiKeys {
+		owner, managedOwner := accountsById[key.GetSpec().GetOwnerId()]
+		if managedOwner && declaredAccounts[strings.ToLower(owner.GetSpec().GetName())] &&
+			!declaredKeys[strings.ToLower(key.GetSpec().GetDisplayName())] {
+			report.UnmanagedApiKeys = append(report.UnmanagedApiKeys,
+				fmt.Sprintf("%s/%s", owner.GetSpec().GetName(), key.GetSpec().GetDisplayName()))
+		}
+		if maxKeyLifetime > 0 {
+			if violation := checkKeyLifetime(key, maxKeyLifetime, now); violation != nil {
+				report.KeysPastLifetime = append(report.KeysPastLifetime, *violation)
+			}
+		}
+	}
+
+	sort.Strings(report.UnmanagedServiceAccounts)
+	sort.Strings(report.UnmanagedApiKeys)
+	return report
+}
+
+// detectEscalation returns the access the Cloud service account holds beyond its declaration, if any
+func detectEscalation(declared ServiceAccount, current *identityv1.ServiceAccount) *RoleEscalation {
+	declaredAccess, err := declared.Access().ToIdentityAccess()
+	if err != nil {
+		// Load validates access, so this only happens for hand-built desired states
+		return nil
+	}
+	actualAccess := current.GetSpec().GetAccess()
+
+	var details []string
+	declaredRole := declaredAccess.GetAccountAccess().GetRole()
+	actualRole := actualAccess.GetAccountAccess().GetRole()
+	if accountRoleRank[actualRole] > accountRoleRank[declaredRole] {
+		details = append(details, fmt.Sprintf("account role %s exceeds declared %s",
+			activities.AccountRoleName(actualRole), activities.AccountRoleName(declaredRole)))
+	}
+
+	namespaces := make([]string, 0, len(actualAccess.GetNamespaceAccesses()))
+	for ns := range actualAccess.GetNamespaceAccesses() {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	for _, ns := range namespaces {
+		actual := actualAccess.GetNamespaceAccesses()[ns].GetPermission()
+		declaredPermission, ok := declaredAccess.GetNamespaceAccesses()[ns]
+		switch {
+		case !ok:
+			details = append(details, fmt.Sprintf("namespace %s has undeclared %s permission",
+				ns, activities.NamespacePermissionName(actual)))
+		case namespacePermissionRank[actual] > namespacePermissionRank[declaredPermission.GetPermission()]:
+			details = append(details, fmt.Sprintf("namespace %s permission %s exceeds declared %s",
+				ns, activities.NamespacePermissionName(actual), activities.NamespacePermissionName(declaredPermission.GetPermission())))
+		}
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+	return &RoleEscalation{
+		ServiceAccount: declared.Name,
+		Declared:       declared.Access().String(),
+		Actual:         activities.AccessFromIdentity(actualAccess).String(),
+		Details:        details,
+	}
+}
+
+// checkKeyLifetime reports keys that were issued for longer than maxKeyLifetime or have been alive longer than it
+func checkKeyLifetime(key *identityv1.ApiKey, maxKeyLifetime time.Duration, now time.Time) *KeyLifetimeViolation {
+	if key.CreatedTime == nil {
+		return nil
+	}
+	created := key.CreatedTime.AsTime()
+	violation := &KeyLifetimeViolation{
+		ApiKey:      key.GetSpec().GetDisplayName(),
+		ApiKeyId:    key.Id,
+		OwnerId:     key.GetSpec().GetOwnerId(),
+		CreatedTime: created,
+	}
+	if expiry := key.GetSpec().GetExpiryTime(); expiry != nil {
+		violation.ExpiryTime = expiry.AsTime()
+		if lifetime := violation.ExpiryTime.Sub(created); lifetime > maxKeyLifetime {
+			violation.Reason = fmt.Sprintf("issued for %s, policy allows %s", lifetime.Round(time.Hour), maxKeyLifetime)
+			return violation
+		}
+	}
+	if age := now.Sub(created); age > maxKeyLifetime {
+		violation.Reason = fmt.Sprintf("in use for %s, policy allows %s", age.Round(time.Hour), maxKeyLifetime)
+		return violation
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package desiredstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDetectDrift(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	desired := &DesiredState{ServiceAccounts: []ServiceAccount{
+		{
+			Name:        "worker",
+			AccountRole: "read",
+			Namespaces:  map[string]string{"orders.abc12": "read"},
+			ApiKeys:     []ApiKey{{Name: "worker-key"}, {Name: "worker-missing"}},
+		},
+		{Name: "missing"},
+	}}
+	key := func(id string, name string, owner string, created time.Time, lifetime time.Duration) *identityv1.ApiKey {
+		k := observedKey(id, name, owner)
+		k.CreatedTime = timestamppb.New(created)
+		k.Spec.ExpiryTime = timestamppb.New(created.Add(lifetime))
+		return k
+	}
+
+	tests := []struct {
+		name     string
+		observed Observed
+		check    func(t *testing.T, report *DriftReport)
+	}{
+		{
+			name: "missing and unmanaged identities",
+			observed: Observed{
+				ServiceAccounts: []*identityv1.ServiceAccount{
+					observedAccount("sa-1", "worker", "", identityv1.AccountAccess_ROLE_READ,
+						map[string]identityv1.NamespaceAccess_Permission{"orders.abc12": identityv1.NamespaceAccess_PERMISSION_READ}),
+					observedAccount("sa-2", "stray", "", identityv1.AccountAccess_ROLE_READ, nil),
+				},
+				ApiKeys: []*identityv1.ApiKey{
+					key("key-1", "worker-key", "sa-1", now.Add(-time.Hour), 24*time.Hour),
+					key("key-2", "worker-extra", "sa-1", now.Add(-time.Hour), 24*time.Hour),
+					key("key-3", "stray-key", "sa-2", now.Add(-time.Hour), 24*time.Hour),
+				},
+			},
+			check: func(t *testing.T, report *DriftReport) {
+				require.Equal(t, []string{"missing"}, report.MissingServiceAccounts)
+				require.Equal(t, []string{"worker/worker-missing"}, report.MissingApiKeys)
+				require.Equal(t, []string{"stray"}, report.UnmanagedServiceAccounts)
+				require.Equal(t, []string{"worker/worker-extra"}, report.UnmanagedApiKeys)
+				require.Empty(t, report.RoleEscalations)
+				require.Empty(t, report.KeysPastLifetime)
+				require.Equal(t, 4, report.FindingCount())
+			},
+		},
+		{
+			name: "role escalations",
+			observed: Observed{
+				ServiceAccounts: []*identityv1.ServiceAccount{
+					observedAccount("sa-1", "worker", "", identityv1.AccountAccess_ROLE_ADMIN, map[string]identityv1.NamespaceAccess_Permission{
+						"orders.abc12":   identityv1.NamespaceAccess_PERMISSION_ADMIN,
+						"payments.abc12": identityv1.NamespaceAccess_PERMISSION_READ,
+					}),
+				},
+			},
+			check: func(t *testing.T, report *DriftReport) {
+				require.Len(t, report.RoleEscalations, 1)
+				require.Equal(t, []string{
+					"account role admin exceeds declared read",
+					"namespace orders.abc12 permission admin exceeds declared read",
+					"namespace payments.abc12 has undeclared read permission",
+				}, report.RoleEscalations[0].Details)
+			},
+		},
+		{
+			name: "less access than declared is not an escalation",
+			observed: Observed{
+				ServiceAccounts: []*identityv1.ServiceAccount{
+					observedAccount("sa-1", "worker", "", identityv1.AccountAccess_ROLE_READ, nil),
+				},
+			},
+			check: func(t *testing.T, report *DriftReport) {
+				require.Empty(t, report.RoleEscalations)
+			},
+		},
+		{
+			name: "keys past the lifetime policy",
+			observed: Observed{
+				ServiceAccounts: []*identityv1.ServiceAccount{
+					observedAccount("sa-1", "worker", "", identityv1.AccountAccess_ROLE_READ,
+						map[string]identityv1.NamespaceAccess_Permission{"orders.abc12": identityv1.NamespaceAccess_PERMISSION_READ}),
+				},
+				ApiKeys: []*identityv1.ApiKey{
+					key("key-1", "worker-key", "sa-1", now.Add(-time.Hour), 365*24*time.Hour),
+					key("key-2", "worker-missing", "sa-1", now.Add(-60*24*time.Hour), 90*24*time.Hour),
+					key("key-3", "worker-old", "sa-1", now.Add(-40*24*time.Hour), 20*24*time.Hour),
+					key("key-4", "worker-new", "sa-1", now.Add(-24*time.Hour), 20*24*time.Hour),
+				},
+			},
+			check: func(t *testing.T, report *DriftReport) {
+				require.Len(t, report.KeysPastLifetime, 3)
+				require.Equal(t, "issued for 8760h0m0s, policy allows 720h0m0s", report.KeysPastLifetime[0].Reason)
+				require.Equal(t, "issued for 2160h0m0s, policy allows 720h0m0s", report.KeysPastLifetime[1].Reason)
+				require.Equal(t, "in use for 960h0m0s, policy allows 720h0m0s", report.KeysPastLifetime[2].Reason)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, DetectDrift(desired, &tt.observed, 30*24*time.Hour, now))
+		})
+	}
+}
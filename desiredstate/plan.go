@@ -0,0 +1,270 @@
+package desiredstate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"temporal-jumpstart-operations/ownership"
+	"temporal-jumpstart-operations/policy"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+)
+
+// ActionType identifies the Cloud mutation an Action performs
+type ActionType string
+
+const (
+	ActionCreateServiceAccount ActionType = "create-service-account"
+	ActionUpdateServiceAccount ActionType = "update-service-account"
+	ActionCreateApiKey         ActionType = "create-api-key"
+)
+
+// Action is a single step needed to converge Cloud to the desired state
+type Action struct {
+	Type ActionType `json:"type"`
+	// ServiceAccountName is the service account the action applies to
+	ServiceAccountName string `json:"serviceAccountName"`
+	// ServiceAccountId is empty when the service account is created by an earlier action of the same plan
+	ServiceAccountId string `json:"serviceAccountId,omitempty"`
+	// Description and Access are set for service account actions
+	Description string             `json:"description,omitempty"`
+	Access      *activities.Access `json:"access,omitempty"`
+	// DescriptionDefaulted is set when the desired state gave no description and Description carries the default
+	DescriptionDefaulted bool `json:"descriptionDefaulted,omitempty"`
+	// ApiKey is set for api key actions
+	ApiKey *ApiKey `json:"apiKey,omitempty"`
+	// Reason explains why the action is needed
+	Reason string `json:"reason"`
+}
+
+// Plan is the ordered list of actions that converges Cloud to the desired state.
+// Service accounts that exist in Cloud but are not declared are reported and never touched.
+type Plan struct {
+	Actions []Action `json:"actions"`
+	// Unmanaged lists Cloud service accounts that are not part of the desired state
+	Unmanaged []string `json:"unmanaged"`
+	// Conflicts lists declarations that cannot be applied, e.g. an api key name owned by another account
+	Conflicts []string `json:"conflicts"`
+}
+
+// Observed is the Cloud state a desired state is compared against
+type Observed struct {
+	ServiceAccounts []*identityv1.ServiceAccount
+	ApiKeys         []*identityv1.ApiKey
+}
+
+// Observe reads every service account and service account API key from Cloud
+func Observe(ctx context.Context, cloudClient cloudservicev1.CloudServiceClient) (*Observed, error) {
+	sas, err := activities.ListServiceAccounts(ctx, cloudClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	keys, err := activities.ListApiKeys(ctx, cloudClient, identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return &Observed{
+		ServiceAccounts: liveServiceAccounts(sas),
+		ApiKeys:         liveApiKeys(keys),
+	}, nil
+}
+
+// ComputePlan diffs the desired state against the observed Cloud state
+func ComputePlan(desired *DesiredState, observed *Observed) *Plan {
+	plan := &Plan{}
+
+	accountsByName := map[string]*identityv1.ServiceAccount{}
+	for _, sa := range observed.ServiceAccounts {
+		accountsByName[strings.ToLower(sa.GetSpec().GetName())] = sa
+	}
+	keysByName := map[string]*identityv1.ApiKey{}
+	for _, key := range observed.ApiKeys {
+		keysByName[strings.ToLower(key.GetSpec().GetDisplayName())] = key
+	}
+
+	declared := map[string]bool{}
+	for _, sa := range desired.ServiceAccounts {
+		declared[strings.ToLower(sa.Name)] = true
+		access := sa.Access()
+
+		current, exists := accountsByName[strings.ToLower(sa.Name)]
+		switch {
+		case !exists:
+			plan.Actions = append(plan.Actions, Action{
+				Type:                 ActionCreateServiceAccount,
+				ServiceAccountName:   sa.Name,
+				Description:          sa.Description,
+				Access:               access,
+				DescriptionDefaulted: sa.DescriptionDefaulted,
+				Reason:               "service account does not exist",
+			})
+		default:
+			currentAccess := activities.AccessFromIdentity(current.GetSpec().GetAccess())
+			var changes []string
+			if current.GetSpec().GetDescription() != sa.Description {
+				changes = append(changes, fmt.Sprintf("description %q -> %q", current.GetSpec().GetDescription(), sa.Description))
+			}
+			if !currentAccess.Equal(access) {
+				changes = append(changes, fmt.Sprintf("access {%s} -> {%s}", currentAccess, access))
+			}
+			if len(changes) > 0 {
+				plan.Actions = append(plan.Actions, Action{
+					Type:                 ActionUpdateServiceAccount,
+					ServiceAccountName:   sa.Name,
+					ServiceAccountId:     current.Id,
+					Description:          sa.Description,
+					Access:               access,
+					DescriptionDefaulted: sa.DescriptionDefaulted,
+					Reason:               strings.Join(changes, "; "),
+				})
+			}
+		}
+
+		for _, key := range sa.ApiKeys {
+			existing, keyExists := keysByName[strings.ToLower(key.Name)]
+			if keyExists {
+				if current == nil || existing.GetSpec().GetOwnerId() != current.Id {
+					plan.Conflicts = append(plan.Conflicts, fmt.Sprintf("api key %s already exists and is owned by %s, not %s",
+						key.Name, existing.GetSpec().GetOwnerId(), sa.Name))
+				}
+				continue
+			}
+			action := Action{
+				Type:               ActionCreateApiKey,
+				ServiceAccountName: sa.Name,
+				ApiKey:             &key,
+				Reason:             "api key does not exist",
+			}
+			if current != nil {
+				action.ServiceAccountId = current.Id
+			}
+			plan.Actions = append(plan.Actions, action)
+		}
+	}
+
+	for _, sa := range observed.ServiceAccounts {
+		if !declared[strings.ToLower(sa.GetSpec().GetName())] {
+			plan.Unmanaged = append(plan.Unmanaged, sa.GetSpec().GetName())
+		}
+	}
+	sort.Strings(plan.Unmanaged)
+
+	return plan
+}
+
+// CheckPolicy evaluates the service accounts and API keys the plan creates or updates against a policy.
+// Defaulted descriptions count as missing and description patterns are matched without the owner tag.
+func (p *Plan) CheckPolicy(pol *policy.Policy) policy.Violations {
+	return pol.Check(p.Identities())
+}
+
+// Identities returns the service accounts and API keys the plan creates or updates, as the policy sees them
+func (p *Plan) Identities() *policy.Identities {
+	identities := &policy.Identities{}
+	for _, action := range p.Actions {
+		switch action.Type {
+		case ActionCreateServiceAccount, ActionUpdateServiceAccount:
+			description, _ := ownership.ParseDescription(action.Description)
+			if action.DescriptionDefaulted {
+				description = ""
+			}
+			identities.ServiceAccounts = append(identities.ServiceAccounts, policy.ServiceAccount{
+				Name:        action.ServiceAccountName,
+				Description: description,
+				AccountRole: action.Access.AccountRole,
+			})
+		case ActionCreateApiKey:
+			description := action.ApiKey.Description
+			if action.ApiKey.DescriptionDefaulted {
+				description = ""
+			}
+			identities.ApiKeys = append(identities.ApiKeys, policy.ApiKey{
+				ServiceAccountName: action.ServiceAccountName,
+				Name:               action.ApiKey.Name,
+				Description:        description,
+				Duration:           action.ApiKey.Duration,
+			})
+		}
+	}
+	return identities
+}
+
+// Privileged describes the actions that grant a privileged account role, which need approval on production accounts
+func (p *Plan) Privileged() []string {
+	var privileged []string
+	for _, action := range p.Actions {
+		if action.Access != nil && policy.IsPrivilegedAccountRole(action.Access.AccountRole) {
+			privileged = append(privileged, fmt.Sprintf("%s %s with account role %s", action.Type, action.ServiceAccountName, action.Access.AccountRole))
+		}
+	}
+	return privileged
+}
+
+// IsEmpty reports whether Cloud already matches the desired state
+func (p *Plan) IsEmpty() bool {
+	return len(p.Actions) == 0
+}
+
+// Print writes a human readable summary of the plan
+func (p *Plan) Print(w io.Writer) {
+	if p.IsEmpty() {
+		fmt.Fprintf(w, "No changes. Temporal Cloud matches the desired state.\n")
+	} else {
+		fmt.Fprintf(w, "Plan: %d change(s)\n", len(p.Actions))
+		for _, action := range p.Actions {
+			switch action.Type {
+			case ActionCreateServiceAccount:
+				fmt.Fprintf(w, "  + service account %s {%s}\n", action.ServiceAccountName, action.Access)
+			case ActionUpdateServiceAccount:
+				fmt.Fprintf(w, "  ~ service account %s (%s): %s\n", action.ServiceAccountName, action.ServiceAccountId, action.Reason)
+			case ActionCreateApiKey:
+				fmt.Fprintf(w, "  + api key %s for %s (duration %s)\n", action.ApiKey.Name, action.ServiceAccountName, action.ApiKey.Duration)
+			}
+		}
+	}
+	if len(p.Unmanaged) > 0 {
+		fmt.Fprintf(w, "Unmanaged service accounts (left untouched):\n")
+		for _, name := range p.Unmanaged {
+			fmt.Fprintf(w, "  ? %s\n", name)
+		}
+	}
+	if len(p.Conflicts) > 0 {
+		fmt.Fprintf(w, "Conflicts:\n")
+		for _, conflict := range p.Conflicts {
+			fmt.Fprintf(w, "  ! %s\n", conflict)
+		}
+	}
+}
+
+// liveServiceAccounts drops service accounts that are deleted or being deleted
+func liveServiceAccounts(sas []*identityv1.ServiceAccount) []*identityv1.ServiceAccount {
+	var result []*identityv1.ServiceAccount
+	for _, sa := range sas {
+		if !isDeleted(sa.State) {
+			result = append(result, sa)
+		}
+	}
+	return result
+}
+
+// liveApiKeys drops api keys that are deleted or being deleted
+func liveApiKeys(keys []*identityv1.ApiKey) []*identityv1.ApiKey {
+	var result []*identityv1.ApiKey
+	for _, key := range keys {
+		if !isDeleted(key.State) {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+func isDeleted(state resourcev1.ResourceState) bool {
+	return state == resourcev1.ResourceState_RESOURCE_STATE_DELETED ||
+		state == resourcev1.ResourceState_RESOURCE_STATE_DELETING
+}
@@ -0,0 +1,137 @@
+package desiredstate
+
+import (
+	"testing"
+
+	"temporal-jumpstart-operations/policy"
+
+	"github.com/stretchr/testify/require"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+)
+
+func observedAccount(id string, name string, description string, role identityv1.AccountAccess_Role, namespaces map[string]identityv1.NamespaceAccess_Permission) *identityv1.ServiceAccount {
+	access := &identityv1.Access{
+		AccountAccess:     &identityv1.AccountAccess{Role: role},
+		NamespaceAccesses: map[string]*identityv1.NamespaceAccess{},
+	}
+	for ns, permission := range namespaces {
+		access.NamespaceAccesses[ns] = &identityv1.NamespaceAccess{Permission: permission}
+	}
+	return &identityv1.ServiceAccount{
+		Id:   id,
+		Spec: &identityv1.ServiceAccountSpec{Name: name, Description: description, Access: access},
+	}
+}
+
+func observedKey(id string, name string, ownerId string) *identityv1.ApiKey {
+	return &identityv1.ApiKey{
+		Id:   id,
+		Spec: &identityv1.ApiKeySpec{DisplayName: name, OwnerId: ownerId},
+	}
+}
+
+func TestComputePlan(t *testing.T) {
+	worker := ServiceAccount{
+		Name:        "worker",
+		Description: "Order worker",
+		AccountRole: "developer",
+		Namespaces:  map[string]string{"orders.abc12": "write"},
+		ApiKeys:     []ApiKey{{Name: "worker-key", Description: "key", Duration: "30d"}},
+	}
+
+	tests := []struct {
+		name      string
+		desired   []ServiceAccount
+		observed  Observed
+		actions   []ActionType
+		reasons   []string
+		unmanaged []string
+		conflicts int
+	}{
+		{
+			name:    "creates a missing account and its keys",
+			desired: []ServiceAccount{worker},
+			actions: []ActionType{ActionCreateServiceAccount, ActionCreateApiKey},
+			reasons: []string{"service account does not exist", "api key does not exist"},
+		},
+		{
+			name:    "leaves a matching account alone",
+			desired: []ServiceAccount{worker},
+			observed: Observed{
+				ServiceAccounts: []*identityv1.ServiceAccount{observedAccount("sa-1", "Worker", "Order worker", identityv1.AccountAccess_ROLE_DEVELOPER,
+					map[string]identityv1.NamespaceAccess_Permission{"orders.abc12": identityv1.NamespaceAccess_PERMISSION_WRITE})},
+				ApiKeys: []*identityv1.ApiKey{observedKey("key-1", "worker-key", "sa-1")},
+			},
+		},
+		{
+			name:    "updates changed access and description",
+			desired: []ServiceAccount{worker},
+			observed: Observed{
+				ServiceAccounts: []*identityv1.ServiceAccount{observedAccount("sa-1", "worker", "Old", identityv1.AccountAccess_ROLE_READ, nil)},
+				ApiKeys:         []*identityv1.ApiKey{observedKey("key-1", "worker-key", "sa-1")},
+			},
+			actions: []ActionType{ActionUpdateServiceAccount},
+			reasons: []string{`description "Old" -> "Order worker"; access {account=read namespaces=[]} -> {account=developer namespaces=[orders.abc12=write]}`},
+		},
+		{
+			name:    "reports a key owned by another account",
+			desired: []ServiceAccount{worker},
+			observed: Observed{
+				ServiceAccounts: []*identityv1.ServiceAccount{observedAccount("sa-1", "worker", "Order worker", identityv1.AccountAccess_ROLE_DEVELOPER,
+					map[string]identityv1.NamespaceAccess_Permission{"orders.abc12": identityv1.NamespaceAccess_PERMISSION_WRITE})},
+				ApiKeys: []*identityv1.ApiKey{observedKey("key-1", "worker-key", "sa-2")},
+			},
+			conflicts: 1,
+		},
+		{
+			name:    "reports undeclared accounts without touching them",
+			desired: []ServiceAccount{worker},
+			observed: Observed{
+				ServiceAccounts: []*identityv1.ServiceAccount{
+					observedAccount("sa-2", "zeta", "", identityv1.AccountAccess_ROLE_ADMIN, nil),
+					observedAccount("sa-3", "alpha", "", identityv1.AccountAccess_ROLE_READ, nil),
+				},
+			},
+			actions:   []ActionType{ActionCreateServiceAccount, ActionCreateApiKey},
+			reasons:   []string{"service account does not exist", "api key does not exist"},
+			unmanaged: []string{"alpha", "zeta"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := ComputePlan(&DesiredState{ServiceAccounts: tt.desired}, &tt.observed)
+
+			var actions []ActionType
+			var reasons []string
+			for _, action := range plan.Actions {
+				actions = append(actions, action.Type)
+				reasons = append(reasons, action.Reason)
+			}
+			require.Equal(t, tt.actions, actions)
+			require.Equal(t, tt.reasons, reasons)
+			require.Equal(t, tt.unmanaged, plan.Unmanaged)
+			require.Len(t, plan.Conflicts, tt.conflicts)
+		})
+	}
+}
+
+func TestPlanCheckPolicyRequiresDescriptions(t *testing.T) {
+	p := &policy.Policy{
+		ServiceAccounts: policy.ServiceAccountRules{RequireDescription: true},
+		ApiKeys:         policy.ApiKeyRules{RequireDescription: true},
+	}
+	state := &DesiredState{ServiceAccounts: []ServiceAccount{{
+		Name:    "worker",
+		ApiKeys: []ApiKey{{Name: "worker-key", OutputPath: "/tmp"}},
+	}}}
+	state.applyDefaults()
+	plan := ComputePlan(state, &Observed{})
+
+	require.Len(t, plan.CheckPolicy(p), 2, "defaulted descriptions do not satisfy the policy")
+
+	state.ServiceAccounts[0].Description = "Order worker"
+	state.ServiceAccounts[0].DescriptionDefaulted = false
+	state.ServiceAccounts[0].ApiKeys[0].DescriptionDefaulted = false
+	require.Empty(t, ComputePlan(state, &Observed{}).CheckPolicy(p))
+}
@@ -0,0 +1,76 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"temporal-jumpstart-operations/audit"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// IDPrefix starts every ID a dry run makes up for a resource that was not created
+const IDPrefix = "dry-run-"
+
+// Output is where a dry run describes the calls and side effects it skipped
+var Output io.Writer = os.Stdout
+
+// Printf describes a skipped call or side effect
+func Printf(format string, args ...any) {
+	fmt.Fprintf(Output, "🧪 Would "+format+"\n", args...)
+}
+
+// UnaryClientInterceptor makes read-only Cloud API calls as usual and prints mutating calls instead of making them.
+// The reply of a skipped call carries placeholder IDs and no async operation, so callers carry on as if it succeeded.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !audit.IsMutating(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		Printf("call %s %s", path.Base(method), describe(req))
+		if m, ok := reply.(proto.Message); ok {
+			fillIDs(m.ProtoReflect())
+		}
+		return nil
+	}
+}
+
+// describe renders a request as compact JSON with secrets redacted
+func describe(req any) string {
+	m, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return ""
+	}
+	redacted, _ := json.Marshal(audit.Redact(fields))
+	return string(redacted)
+}
+
+// fillIDs sets every top level *_id string field of a reply to a placeholder, e.g. dry-run-service-account
+func fillIDs(reply protoreflect.Message) {
+	fields := reply.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		name := string(field.Name())
+		if field.Kind() != protoreflect.StringKind || field.IsList() || !strings.HasSuffix(name, "_id") {
+			continue
+		}
+		placeholder := IDPrefix + strings.ReplaceAll(strings.TrimSuffix(name, "_id"), "_", "-")
+		reply.Set(field, protoreflect.ValueOfString(placeholder))
+	}
+}
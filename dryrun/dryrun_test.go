@@ -0,0 +1,101 @@
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"google.golang.org/grpc"
+)
+
+const cloudService = "/temporal.api.cloud.cloudservice.v1.CloudService/"
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		req       any
+		reply     any
+		invoked   bool
+		output    string
+		repliedID func(reply any) string
+	}{
+		{
+			name:    "get is made",
+			method:  "GetServiceAccounts",
+			req:     &cloudservicev1.GetServiceAccountsRequest{},
+			reply:   &cloudservicev1.GetServiceAccountsResponse{},
+			invoked: true,
+		},
+		{
+			name:    "validate is made",
+			method:  "ValidateNamespaceExportSink",
+			req:     &cloudservicev1.ValidateNamespaceExportSinkRequest{},
+			reply:   &cloudservicev1.ValidateNamespaceExportSinkResponse{},
+			invoked: true,
+		},
+		{
+			name:   "create is skipped with placeholder ids",
+			method: "CreateServiceAccount",
+			req: &cloudservicev1.CreateServiceAccountRequest{
+				Spec: &identityv1.ServiceAccountSpec{Name: "worker"},
+			},
+			reply:  &cloudservicev1.CreateServiceAccountResponse{},
+			output: `🧪 Would call CreateServiceAccount {"spec":{"name":"worker"}}` + "\n",
+			repliedID: func(reply any) string {
+				return reply.(*cloudservicev1.CreateServiceAccountResponse).ServiceAccountId
+			},
+		},
+		{
+			name:   "api key creation is skipped",
+			method: "CreateApiKey",
+			req: &cloudservicev1.CreateApiKeyRequest{
+				Spec: &identityv1.ApiKeySpec{DisplayName: "worker-key", OwnerId: "sa-1"},
+			},
+			reply:  &cloudservicev1.CreateApiKeyResponse{Token: "t0ken"},
+			output: `🧪 Would call CreateApiKey {"spec":{"displayName":"worker-key","ownerId":"sa-1"}}` + "\n",
+			repliedID: func(reply any) string {
+				return reply.(*cloudservicev1.CreateApiKeyResponse).KeyId
+			},
+		},
+		{
+			name:   "update is skipped",
+			method: "UpdateUser",
+			req:    &cloudservicev1.UpdateUserRequest{UserId: "user-1"},
+			reply:  &cloudservicev1.UpdateUserResponse{},
+			output: `🧪 Would call UpdateUser {"userId":"user-1"}` + "\n",
+		},
+		{
+			name:   "delete is skipped",
+			method: "DeleteServiceAccount",
+			req:    &cloudservicev1.DeleteServiceAccountRequest{ServiceAccountId: "sa-1"},
+			reply:  &cloudservicev1.DeleteServiceAccountResponse{},
+			output: `🧪 Would call DeleteServiceAccount {"serviceAccountId":"sa-1"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			previous := Output
+			Output = &output
+			t.Cleanup(func() { Output = previous })
+
+			invoked := false
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				invoked = true
+				return nil
+			}
+			err := UnaryClientInterceptor()(context.Background(), cloudService+tt.method, tt.req, tt.reply, nil, invoker)
+			require.NoError(t, err)
+			require.Equal(t, tt.invoked, invoked)
+			require.Equal(t, tt.output, output.String())
+			if tt.repliedID != nil {
+				require.Contains(t, tt.repliedID(tt.reply), IDPrefix)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Notifier schemes; a plain http(s) URL is a generic JSON webhook
+const (
+	SchemeSlack = "slack"
+	SchemeTeams = "teams"
+	SchemeSMTP  = "smtp"
+)
+
+// Event statuses
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Event is the outcome of an operation teams are told about
+type Event struct {
+	// Operation is what ran, e.g. 'create service account'
+	Operation string `json:"operation"`
+	// Subject is what the operation ran on, e.g. the service account name
+	Subject string `json:"subject,omitempty"`
+	// Team owns the subject; notifiers routed to the team are told as well
+	Team string `json:"team,omitempty"`
+	// Status is succeeded or failed
+	Status string `json:"status"`
+	// Error explains a failed operation
+	Error string `json:"error,omitempty"`
+	// WorkflowID and RunID identify the workflow that ran the operation
+	WorkflowID string `json:"workflowId,omitempty"`
+	RunID      string `json:"runId,omitempty"`
+	// Details are extra facts about the outcome, e.g. counts
+	Details map[string]string `json:"details,omitempty"`
+	// Time is when the operation finished
+	Time time.Time `json:"time"`
+}
+
+// Failed reports whether the operation failed
+func (e *Event) Failed() bool {
+	return e.Status == StatusFailed
+}
+
+// Message is an Event rendered for people
+type Message struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+	Event *Event `json:"event"`
+}
+
+// Notifier delivers messages to a team
+type Notifier interface {
+	// Notify delivers one message
+	Notify(ctx context.Context, message *Message) error
+}
+
+// ParseNotifier returns the notifier for a URI:
+//   - slack+https://hooks.slack.com/services/... or teams+https://... incoming webhooks
+//   - an http(s) URL the message is POSTed to as JSON, with ?token-env=NAME sending a bearer token from $NAME
+//   - smtp://[user@]host:port?from=ops@example.com&to=a@example.com,b@example.com&password-env=NAME
+func ParseNotifier(uri string) (Notifier, error) {
+	if uri == "" {
+		return nil, fmt.Errorf("a notifier is required")
+	}
+	scheme, _, found := strings.Cut(uri, "://")
+	if !found {
+		return nil, fmt.Errorf("invalid notifier %q, expected a URL", uri)
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notifier %q: %w", uri, err)
+	}
+	switch scheme {
+	case "http", "https":
+		query := u.Query()
+		webhook := &JSONWebhook{TokenEnv: query.Get("token-env")}
+		query.Del("token-env")
+		u.RawQuery = query.Encode()
+		webhook.URL = u.String()
+		return webhook, nil
+	case SchemeSlack + "+http", SchemeSlack + "+https", SchemeTeams + "+http", SchemeTeams + "+https":
+		format, target, _ := strings.Cut(uri, "+")
+		return &IncomingWebhook{URL: target, Format: format}, nil
+	case SchemeSMTP:
+		return parseSMTP(u)
+	default:
+		return nil, fmt.Errorf("unsupported notifier scheme %q", scheme)
+	}
+}
+
+// Multi delivers every message to all of its notifiers
+type Multi []Notifier
+
+// Notify delivers the message to every notifier, even when some of them fail
+func (m Multi) Notify(ctx context.Context, message *Message) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Router delivers every message to the default notifier and to the notifier of the team the event belongs to
+type Router struct {
+	// Default is told about every event (optional)
+	Default Notifier
+	// Teams map an owner team to its notifier
+	Teams map[string]Notifier
+}
+
+// Notify delivers the message to the default and team notifiers
+func (r *Router) Notify(ctx context.Context, message *Message) error {
+	var notifiers Multi
+	if r.Default != nil {
+		notifiers = append(notifiers, r.Default)
+	}
+	if message.Event != nil && message.Event.Team != "" {
+		for team, notifier := range r.Teams {
+			if strings.EqualFold(team, message.Event.Team) {
+				notifiers = append(notifiers, notifier)
+			}
+		}
+	}
+	return notifiers.Notify(ctx, message)
+}
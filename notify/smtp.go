@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultSMTPPort is the submission port used when the URI has none
+const defaultSMTPPort = "587"
+
+// SMTPNotifier emails messages. The connection is upgraded with STARTTLS when the server offers it.
+type SMTPNotifier struct {
+	// Address is the host:port of the mail server
+	Address string
+	// Username authenticates with PLAIN auth (optional)
+	Username string
+	// PasswordEnv names the environment variable holding the password (optional)
+	PasswordEnv string
+	// From is the sender address
+	From string
+	// To are the recipient addresses
+	To []string
+}
+
+// parseSMTP returns the SMTP notifier for smtp://[user@]host[:port]?from=...&to=a,b&password-env=NAME
+func parseSMTP(u *url.URL) (*SMTPNotifier, error) {
+	query := u.Query()
+	notifier := &SMTPNotifier{
+		Address:     u.Host,
+		Username:    u.User.Username(),
+		PasswordEnv: query.Get("password-env"),
+		From:        query.Get("from"),
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("smtp notifier needs a host")
+	}
+	if u.Port() == "" {
+		notifier.Address = net.JoinHostPort(u.Hostname(), defaultSMTPPort)
+	}
+	for _, to := range strings.Split(query.Get("to"), ",") {
+		if to = strings.TrimSpace(to); to != "" {
+			notifier.To = append(notifier.To, to)
+		}
+	}
+	if notifier.From == "" || len(notifier.To) == 0 {
+		return nil, fmt.Errorf("smtp notifier needs ?from= and ?to= addresses")
+	}
+	return notifier, nil
+}
+
+// Notify sends the message as a plain text email
+func (n *SMTPNotifier) Notify(ctx context.Context, message *Message) error {
+	var auth smtp.Auth
+	if n.Username != "" {
+		password := ""
+		if n.PasswordEnv != "" {
+			if password = os.Getenv(n.PasswordEnv); password == "" {
+				return fmt.Errorf("$%s is not set", n.PasswordEnv)
+			}
+		}
+		host, _, _ := net.SplitHostPort(n.Address)
+		auth = smtp.PlainAuth("", n.Username, password, host)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", n.From)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Title))
+	fmt.Fprintf(&body, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&body, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&body, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	body.WriteString(strings.ReplaceAll(message.Text, "\n", "\r\n"))
+	body.WriteString("\r\n")
+
+	// net/smtp has no context support, so the send is abandoned rather than interrupted on cancellation
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(n.Address, auth, n.From, n.To, body.Bytes())
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send notification email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
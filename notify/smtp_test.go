@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// smtpSession is what the stub SMTP server received
+type smtpSession struct {
+	Auth       string
+	From       string
+	Recipients []string
+	Data       string
+}
+
+// newStubSMTPServer accepts one SMTP session, offering AUTH PLAIN but no STARTTLS, and returns its address
+func newStubSMTPServer(t *testing.T) (string, <-chan *smtpSession) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	sessions := make(chan *smtpSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		session := &smtpSession{}
+		defer func() { sessions <- session }()
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost ESMTP stub")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.TrimRight(line, "\r\n")
+			verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0])
+			switch {
+			case verb == "EHLO":
+				reply("250-localhost")
+				reply("250 AUTH PLAIN")
+			case verb == "AUTH":
+				session.Auth = strings.TrimPrefix(command, "AUTH PLAIN ")
+				reply("235 2.7.0 Authentication successful")
+			case strings.HasPrefix(strings.ToUpper(command), "MAIL FROM:"):
+				session.From = command[len("MAIL FROM:"):]
+				reply("250 OK")
+			case strings.HasPrefix(strings.ToUpper(command), "RCPT TO:"):
+				session.Recipients = append(session.Recipients, command[len("RCPT TO:"):])
+				reply("250 OK")
+			case verb == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				session.Data = data.String()
+				reply("250 OK")
+			case verb == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	return listener.Addr().String(), sessions
+}
+
+func TestSMTPNotifierSendsMail(t *testing.T) {
+	t.Setenv("OPS_SMTP_PASSWORD", "hunter2")
+	address, sessions := newStubSMTPServer(t)
+	notifier, err := ParseNotifier("smtp://ops@" + address + "?from=ops@example.com&to=a@example.com,%20b@example.com&password-env=OPS_SMTP_PASSWORD")
+	require.NoError(t, err)
+
+	message := testMessage(t, StatusFailed)
+	require.NoError(t, notifier.Notify(context.Background(), message))
+	session := <-sessions
+
+	auth, err := base64.StdEncoding.DecodeString(session.Auth)
+	require.NoError(t, err)
+	require.Equal(t, "\x00ops\x00hunter2", string(auth))
+	require.Equal(t, "<ops@example.com>", session.From)
+	require.Equal(t, []string{"<a@example.com>", "<b@example.com>"}, session.Recipients)
+
+	headers, body, found := strings.Cut(session.Data, "\r\n\r\n")
+	require.True(t, found)
+	require.Contains(t, headers, "From: ops@example.com\r\n")
+	require.Contains(t, headers, "To: a@example.com, b@example.com\r\n")
+	require.Contains(t, headers, "Content-Type: text/plain; charset=utf-8")
+	require.Contains(t, headers, "Subject: =?utf-8?q?")
+	require.Equal(t, strings.ReplaceAll(message.Text, "\n", "\r\n")+"\r\n", body)
+}
+
+func TestSMTPNotifierMissingPassword(t *testing.T) {
+	t.Setenv("OPS_SMTP_PASSWORD", "")
+	notifier, err := ParseNotifier("smtp://ops@127.0.0.1:2525?from=ops@example.com&to=a@example.com&password-env=OPS_SMTP_PASSWORD")
+	require.NoError(t, err)
+
+	err = notifier.Notify(context.Background(), testMessage(t, StatusSucceeded))
+	require.ErrorContains(t, err, "$OPS_SMTP_PASSWORD is not set")
+}
+
+func TestParseSMTPDefaultsToSubmissionPort(t *testing.T) {
+	notifier, err := ParseNotifier("smtp://mail.example.com?from=ops@example.com&to=a@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "mail.example.com:587", notifier.(*SMTPNotifier).Address)
+
+	_, err = ParseNotifier("smtp://mail.example.com?from=ops@example.com")
+	require.ErrorContains(t, err, "needs ?from= and ?to=")
+}
@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// defaultTemplates define the title and text of a message. A template file can redefine either of them.
+const defaultTemplates = `
+{{- define "title" -}}
+{{if .Failed}}❌{{else}}✅{{end}} {{.Operation}}{{with .Subject}} {{.}}{{end}} {{.Status}}
+{{- end -}}
+
+{{- define "text" -}}
+{{- if .Failed}}Error: {{.Error}}
+{{end -}}
+{{- with .Team}}Team: {{.}}
+{{end -}}
+{{- range $name, $value := .Details}}{{$name}}: {{$value}}
+{{end -}}
+Workflow: {{.WorkflowID}}
+{{- end -}}
+`
+
+// Templates render events into messages
+type Templates struct {
+	template *template.Template
+}
+
+// DefaultTemplates returns the built-in templates
+func DefaultTemplates() *Templates {
+	return &Templates{template: template.Must(template.New("notify").Parse(defaultTemplates))}
+}
+
+// ParseTemplates returns the built-in templates with the "title" and "text" templates the source defines
+// in their place, e.g. {{define "title"}}{{.Operation}} {{.Status}}{{end}}
+func ParseTemplates(source string) (*Templates, error) {
+	t := DefaultTemplates()
+	if _, err := t.template.Parse(source); err != nil {
+		return nil, fmt.Errorf("failed to parse notification templates: %w", err)
+	}
+	return t, nil
+}
+
+// LoadTemplates reads a template file, see ParseTemplates
+func LoadTemplates(path string) (*Templates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification templates: %w", err)
+	}
+	return ParseTemplates(string(data))
+}
+
+// Render turns an event into a message; nil templates are the built-in ones
+func (t *Templates) Render(event *Event) (*Message, error) {
+	if t == nil {
+		t = DefaultTemplates()
+	}
+	message := &Message{Event: event}
+	for name, target := range map[string]*string{"title": &message.Title, "text": &message.Text} {
+		var buf bytes.Buffer
+		if err := t.template.ExecuteTemplate(&buf, name, event); err != nil {
+			return nil, fmt.Errorf("failed to render notification %s: %w", name, err)
+		}
+		*target = strings.TrimSpace(buf.String())
+	}
+	return message, nil
+}
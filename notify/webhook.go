@@ -0,0 +1,131 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+)
+
+// Theme colours of the incoming webhook payloads
+const (
+	colorSucceeded = "2EB886"
+	colorFailed    = "D13438"
+)
+
+// JSONWebhook POSTs the rendered message, with the event it was rendered from, as JSON
+type JSONWebhook struct {
+	URL string
+	// TokenEnv names the environment variable holding a bearer token (optional)
+	TokenEnv string
+	// Client is the HTTP client (optional, defaults to one with a 10s timeout)
+	Client *http.Client
+}
+
+// Notify POSTs the message and fails on a non-2xx status
+func (w *JSONWebhook) Notify(ctx context.Context, message *Message) error {
+	token := ""
+	if w.TokenEnv != "" {
+		if token = os.Getenv(w.TokenEnv); token == "" {
+			return fmt.Errorf("$%s is not set", w.TokenEnv)
+		}
+	}
+	return postJSON(ctx, w.Client, w.URL, token, message)
+}
+
+// IncomingWebhook posts to a Slack or Microsoft Teams incoming webhook in the payload shape it expects
+type IncomingWebhook struct {
+	URL string
+	// Format is slack or teams
+	Format string
+	// Client is the HTTP client (optional, defaults to one with a 10s timeout)
+	Client *http.Client
+}
+
+// Notify posts the message and fails on a non-2xx status
+func (w *IncomingWebhook) Notify(ctx context.Context, message *Message) error {
+	color := colorSucceeded
+	if message.Event != nil && message.Event.Failed() {
+		color = colorFailed
+	}
+
+	var payload any
+	switch w.Format {
+	case SchemeSlack:
+		var fields []map[string]any
+		for _, name := range detailNames(message.Event) {
+			fields = append(fields, map[string]any{"title": name, "value": message.Event.Details[name], "short": true})
+		}
+		payload = map[string]any{
+			"text": message.Title,
+			"attachments": []map[string]any{{
+				"color":  "#" + color,
+				"text":   message.Text,
+				"fields": fields,
+			}},
+		}
+	case SchemeTeams:
+		var facts []map[string]string
+		for _, name := range detailNames(message.Event) {
+			facts = append(facts, map[string]string{"name": name, "value": message.Event.Details[name]})
+		}
+		payload = map[string]any{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"themeColor": color,
+			"summary":    message.Title,
+			"title":      message.Title,
+			"text":       message.Text,
+			"sections":   []map[string]any{{"facts": facts}},
+		}
+	default:
+		return fmt.Errorf("unsupported incoming webhook format %q", w.Format)
+	}
+	return postJSON(ctx, w.Client, w.URL, "", payload)
+}
+
+// detailNames returns the names of the event details in lexical order
+func detailNames(event *Event) []string {
+	if event == nil {
+		return nil
+	}
+	names := make([]string, 0, len(event.Details))
+	for name := range event.Details {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// postJSON POSTs the payload and fails on a non-2xx status
+func postJSON(ctx context.Context, client *http.Client, url string, token string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification webhook returned %s", resp.Status)
+	}
+	return nil
+}
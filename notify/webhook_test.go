@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// capturedRequest is what the test webhook server received
+type capturedRequest struct {
+	ContentType   string
+	Authorization string
+	Body          map[string]any
+}
+
+func newTestWebhookServer(t *testing.T, status int) (*httptest.Server, *capturedRequest) {
+	t.Helper()
+	captured := &capturedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		captured.ContentType = r.Header.Get("Content-Type")
+		captured.Authorization = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&captured.Body))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, captured
+}
+
+func testMessage(t *testing.T, status string) *Message {
+	t.Helper()
+	event := &Event{
+		Operation:  "create service account",
+		Subject:    "billing-worker",
+		Team:       "billing",
+		Status:     status,
+		WorkflowID: "create-service-account-billing-worker",
+		Details:    map[string]string{"namespace": "billing.a1b2c", "account role": "developer"},
+		Time:       time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	if status == StatusFailed {
+		event.Error = "namespace not found"
+	}
+	message, err := DefaultTemplates().Render(event)
+	require.NoError(t, err)
+	return message
+}
+
+func TestIncomingWebhookSlackPayload(t *testing.T) {
+	server, captured := newTestWebhookServer(t, http.StatusOK)
+	notifier, err := ParseNotifier("slack+" + server.URL + "/services/T000/B000/XXX")
+	require.NoError(t, err)
+
+	message := testMessage(t, StatusFailed)
+	require.NoError(t, notifier.Notify(context.Background(), message))
+
+	require.Equal(t, "application/json", captured.ContentType)
+	require.Empty(t, captured.Authorization)
+	require.Equal(t, message.Title, captured.Body["text"])
+	attachments := captured.Body["attachments"].([]any)
+	require.Len(t, attachments, 1)
+	attachment := attachments[0].(map[string]any)
+	require.Equal(t, "#"+colorFailed, attachment["color"])
+	require.Equal(t, message.Text, attachment["text"])
+	require.Equal(t, []any{
+		map[string]any{"title": "account role", "value": "developer", "short": true},
+		map[string]any{"title": "namespace", "value": "billing.a1b2c", "short": true},
+	}, attachment["fields"])
+}
+
+func TestIncomingWebhookTeamsPayload(t *testing.T) {
+	server, captured := newTestWebhookServer(t, http.StatusOK)
+	notifier, err := ParseNotifier("teams+" + server.URL + "/webhookb2/abc")
+	require.NoError(t, err)
+
+	message := testMessage(t, StatusSucceeded)
+	require.NoError(t, notifier.Notify(context.Background(), message))
+
+	require.Equal(t, "MessageCard", captured.Body["@type"])
+	require.Equal(t, "https://schema.org/extensions", captured.Body["@context"])
+	require.Equal(t, colorSucceeded, captured.Body["themeColor"])
+	require.Equal(t, message.Title, captured.Body["summary"])
+	require.Equal(t, message.Title, captured.Body["title"])
+	require.Equal(t, message.Text, captured.Body["text"])
+	require.Equal(t, []any{map[string]any{"facts": []any{
+		map[string]any{"name": "account role", "value": "developer"},
+		map[string]any{"name": "namespace", "value": "billing.a1b2c"},
+	}}}, captured.Body["sections"])
+}
+
+func TestJSONWebhookBodyAndToken(t *testing.T) {
+	t.Setenv("OPS_WEBHOOK_TOKEN", "s3cret")
+	server, captured := newTestWebhookServer(t, http.StatusAccepted)
+	notifier, err := ParseNotifier(server.URL + "/hooks/ops?channel=ops&token-env=OPS_WEBHOOK_TOKEN")
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/hooks/ops?channel=ops", notifier.(*JSONWebhook).URL)
+
+	message := testMessage(t, StatusSucceeded)
+	require.NoError(t, notifier.Notify(context.Background(), message))
+
+	require.Equal(t, "application/json", captured.ContentType)
+	require.Equal(t, "Bearer s3cret", captured.Authorization)
+	require.Equal(t, message.Title, captured.Body["title"])
+	require.Equal(t, message.Text, captured.Body["text"])
+	event := captured.Body["event"].(map[string]any)
+	require.Equal(t, "create service account", event["operation"])
+	require.Equal(t, "billing-worker", event["subject"])
+	require.Equal(t, "billing", event["team"])
+	require.Equal(t, StatusSucceeded, event["status"])
+	require.Equal(t, "create-service-account-billing-worker", event["workflowId"])
+	require.Equal(t, "2025-01-02T03:04:05Z", event["time"])
+	require.NotContains(t, event, "error")
+}
+
+func TestJSONWebhookMissingToken(t *testing.T) {
+	t.Setenv("OPS_WEBHOOK_TOKEN", "")
+	server, _ := newTestWebhookServer(t, http.StatusOK)
+	notifier, err := ParseNotifier(server.URL + "?token-env=OPS_WEBHOOK_TOKEN")
+	require.NoError(t, err)
+
+	err = notifier.Notify(context.Background(), testMessage(t, StatusSucceeded))
+	require.ErrorContains(t, err, "$OPS_WEBHOOK_TOKEN is not set")
+}
+
+func TestWebhookErrorStatus(t *testing.T) {
+	server, _ := newTestWebhookServer(t, http.StatusInternalServerError)
+	notifier, err := ParseNotifier(server.URL)
+	require.NoError(t, err)
+
+	err = notifier.Notify(context.Background(), testMessage(t, StatusSucceeded))
+	require.ErrorContains(t, err, "500 Internal Server Error")
+}
@@ -0,0 +1,72 @@
+package ownership
+
+import (
+	"strings"
+)
+
+// The owner tag appended to a service account description, e.g.
+//
+//	Billing worker [owner team=payments; contact=payments@example.com; costCenter=CC-42; tickets=OPS-1,OPS-7]
+const (
+	tagPrefix          = "[owner "
+	tagSuffix          = "]"
+	fieldSeparator     = "; "
+	ticketSeparator    = ","
+	reservedCharacters = ";[]="
+
+	keyTeam       = "team"
+	keyContact    = "contact"
+	keyCostCenter = "costCenter"
+	keyTickets    = "tickets"
+)
+
+// EncodeDescription returns the description with the owner tag appended, replacing a tag it already has
+func EncodeDescription(description string, owner *Owner) string {
+	description, _ = ParseDescription(description)
+	if owner.IsZero() {
+		return description
+	}
+	var fields []string
+	for _, field := range owner.fields() {
+		fields = append(fields, field[0]+"="+field[1])
+	}
+	tag := tagPrefix + strings.Join(fields, fieldSeparator) + tagSuffix
+	if description == "" {
+		return tag
+	}
+	return description + " " + tag
+}
+
+// ParseDescription splits a description into the text and the owner its tag records, nil without a tag
+func ParseDescription(description string) (string, *Owner) {
+	start := strings.LastIndex(description, tagPrefix)
+	if start < 0 || !strings.HasSuffix(description, tagSuffix) {
+		return description, nil
+	}
+
+	owner := &Owner{}
+	tag := strings.TrimSuffix(description[start+len(tagPrefix):], tagSuffix)
+	for _, field := range strings.Split(tag, ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(field), "=")
+		if !found {
+			return description, nil
+		}
+		switch key {
+		case keyTeam:
+			owner.Team = value
+		case keyContact:
+			owner.Contact = value
+		case keyCostCenter:
+			owner.CostCenter = value
+		case keyTickets:
+			owner.Tickets = strings.Split(value, ticketSeparator)
+		default:
+			// text that merely looks like a tag is part of the description
+			return description, nil
+		}
+	}
+	if owner.IsZero() {
+		return description, nil
+	}
+	return strings.TrimSpace(description[:start]), owner
+}
@@ -0,0 +1,104 @@
+package ownership
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescriptionRoundTrip(t *testing.T) {
+	payments := &Owner{Team: "payments", Contact: "payments@example.com", CostCenter: "CC-42", Tickets: []string{"OPS-1", "OPS-7"}}
+
+	tests := []struct {
+		name        string
+		description string
+		owner       *Owner
+		encoded     string
+		text        string
+	}{
+		{
+			name:        "owner appended",
+			description: "Billing worker",
+			owner:       payments,
+			encoded:     "Billing worker [owner team=payments; contact=payments@example.com; costCenter=CC-42; tickets=OPS-1,OPS-7]",
+			text:        "Billing worker",
+		},
+		{
+			name:    "owner only",
+			owner:   &Owner{Team: "payments"},
+			encoded: "[owner team=payments]",
+		},
+		{
+			name:        "no owner",
+			description: "Billing worker",
+			encoded:     "Billing worker",
+			text:        "Billing worker",
+		},
+		{
+			name:        "existing tag is replaced",
+			description: "Billing worker [owner team=billing]",
+			owner:       &Owner{Team: "payments"},
+			encoded:     "Billing worker [owner team=payments]",
+			text:        "Billing worker",
+		},
+		{
+			name:        "text mentioning an owner tag",
+			description: "Replaces the [owner team=x] convention",
+			owner:       &Owner{Team: "payments"},
+			encoded:     "Replaces the [owner team=x] convention [owner team=payments]",
+			text:        "Replaces the [owner team=x] convention",
+		},
+		{
+			name:        "text ending like a tag without fields",
+			description: "Rotated by [owner on call]",
+			owner:       &Owner{Team: "payments"},
+			encoded:     "Rotated by [owner on call] [owner team=payments]",
+			text:        "Rotated by [owner on call]",
+		},
+		{
+			name:        "text ending like a tag with unknown fields",
+			description: "Cache [owner note=legacy]",
+			owner:       &Owner{Team: "payments"},
+			encoded:     "Cache [owner note=legacy] [owner team=payments]",
+			text:        "Cache [owner note=legacy]",
+		},
+		{
+			name:        "unclosed tag text",
+			description: "Worker [owner",
+			owner:       &Owner{Contact: "ops@example.com"},
+			encoded:     "Worker [owner [owner contact=ops@example.com]",
+			text:        "Worker [owner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := EncodeDescription(tt.description, tt.owner)
+			require.Equal(t, tt.encoded, encoded)
+
+			text, owner := ParseDescription(encoded)
+			require.Equal(t, tt.text, text)
+			if tt.owner.IsZero() {
+				require.Nil(t, owner)
+			} else {
+				require.Equal(t, tt.owner, owner)
+			}
+			// encoding is idempotent
+			require.Equal(t, encoded, EncodeDescription(encoded, tt.owner))
+		})
+	}
+}
+
+func TestParseDescriptionWithoutTag(t *testing.T) {
+	for _, description := range []string{
+		"",
+		"Billing worker",
+		"Billing worker [owner ]",
+		"Billing worker [owner team=payments] and more",
+		"Cache [owner note=legacy]",
+	} {
+		text, owner := ParseDescription(description)
+		require.Equal(t, description, text)
+		require.Nil(t, owner)
+	}
+}
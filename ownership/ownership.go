@@ -0,0 +1,110 @@
+package ownership
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Owner is who a service account belongs to, so alerts and notifications reach the right team
+type Owner struct {
+	// Team is the owning team, e.g. payments
+	Team string `yaml:"team,omitempty" json:"team,omitempty"`
+	// Contact is how to reach the owner, e.g. an email address or a chat channel
+	Contact string `yaml:"contact,omitempty" json:"contact,omitempty"`
+	// CostCenter is the cost center the service account is billed to
+	CostCenter string `yaml:"costCenter,omitempty" json:"costCenter,omitempty"`
+	// Tickets reference the requests the service account was created or changed for, e.g. OPS-123
+	Tickets []string `yaml:"tickets,omitempty" json:"tickets,omitempty"`
+}
+
+// IsZero reports whether no ownership is recorded
+func (o *Owner) IsZero() bool {
+	return o == nil || (o.Team == "" && o.Contact == "" && o.CostCenter == "" && len(o.Tickets) == 0)
+}
+
+// TeamName returns the owning team, or an empty string for a nil owner
+func (o *Owner) TeamName() string {
+	if o == nil {
+		return ""
+	}
+	return o.Team
+}
+
+// Matches reports whether the owner's team or contact is the query, ignoring case
+func (o *Owner) Matches(query string) bool {
+	if o == nil {
+		return false
+	}
+	return strings.EqualFold(o.Team, query) || strings.EqualFold(o.Contact, query)
+}
+
+// Validate checks that no value contains the characters the description tag is delimited with
+func (o *Owner) Validate() error {
+	if o == nil {
+		return nil
+	}
+	for _, ticket := range o.Tickets {
+		if ticket == "" || strings.Contains(ticket, ticketSeparator) {
+			return fmt.Errorf("invalid ticket reference %q", ticket)
+		}
+	}
+	for _, field := range o.fields() {
+		if strings.ContainsAny(field[1], reservedCharacters) {
+			return fmt.Errorf("owner %s %q must not contain any of %q", field[0], field[1], reservedCharacters)
+		}
+	}
+	return nil
+}
+
+// Merge returns the owner with the empty fields filled in from fallback
+func (o *Owner) Merge(fallback *Owner) *Owner {
+	if o == nil {
+		return fallback
+	}
+	if fallback == nil {
+		return o
+	}
+	merged := *o
+	if merged.Team == "" {
+		merged.Team = fallback.Team
+	}
+	if merged.Contact == "" {
+		merged.Contact = fallback.Contact
+	}
+	if merged.CostCenter == "" {
+		merged.CostCenter = fallback.CostCenter
+	}
+	if len(merged.Tickets) == 0 {
+		merged.Tickets = slices.Clone(fallback.Tickets)
+	}
+	return &merged
+}
+
+// String renders the owner as team=..., contact=..., costCenter=..., tickets=...
+func (o *Owner) String() string {
+	if o.IsZero() {
+		return "-"
+	}
+	var parts []string
+	for _, field := range o.fields() {
+		parts = append(parts, field[0]+"="+field[1])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// fields returns the non-empty key and value pairs in tag order
+func (o *Owner) fields() [][2]string {
+	var fields [][2]string
+	for _, field := range [][2]string{
+		{keyTeam, o.Team},
+		{keyContact, o.Contact},
+		{keyCostCenter, o.CostCenter},
+		{keyTickets, strings.Join(o.Tickets, ticketSeparator)},
+	} {
+		if field[1] != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,81 @@
+package ownership
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultRegistryFile is the owner registry name inside the state directory
+const DefaultRegistryFile = "owners.yaml"
+
+// Registry records owners locally, for service accounts whose Cloud description should not carry an owner tag
+type Registry struct {
+	// ServiceAccounts maps a service account name to its owner
+	ServiceAccounts map[string]*Owner `yaml:"serviceAccounts"`
+}
+
+// LoadRegistry reads a registry file; a missing file is an empty registry
+func LoadRegistry(path string) (*Registry, error) {
+	registry := &Registry{ServiceAccounts: map[string]*Owner{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read owner registry: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(registry); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse owner registry %s: %w", path, err)
+	}
+	if registry.ServiceAccounts == nil {
+		registry.ServiceAccounts = map[string]*Owner{}
+	}
+	return registry, nil
+}
+
+// Save writes the registry file
+func (r *Registry) Save(path string) error {
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode owner registry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create owner registry directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write owner registry: %w", err)
+	}
+	return nil
+}
+
+// Lookup returns the owner recorded for a service account, or nil
+func (r *Registry) Lookup(name string) *Owner {
+	if r == nil {
+		return nil
+	}
+	return r.ServiceAccounts[name]
+}
+
+// Set records the owner of a service account; a zero owner removes it
+func (r *Registry) Set(name string, owner *Owner) {
+	if owner.IsZero() {
+		delete(r.ServiceAccounts, name)
+		return
+	}
+	r.ServiceAccounts[name] = owner
+}
+
+// Resolve returns the owner of a service account: the owner tag of its description, completed from the registry
+func (r *Registry) Resolve(name string, description string) *Owner {
+	_, owner := ParseDescription(description)
+	return owner.Merge(r.Lookup(name))
+}
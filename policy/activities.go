@@ -0,0 +1,66 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/sdk/temporal"
+)
+
+var TypeActivities *Activities
+
+// Identities are the service accounts and API keys an operation is about to create or update
+type Identities struct {
+	ServiceAccounts []ServiceAccount `json:"serviceAccounts,omitempty"`
+	ApiKeys         []ApiKey         `json:"apiKeys,omitempty"`
+}
+
+// ApprovalRequirement is the sign-off a privileged operation needs, as the worker resolved it
+type ApprovalRequirement struct {
+	// Approval is nil when the Cloud account the worker manages is not production
+	Approval *Approval `json:"approval,omitempty"`
+	// AccountID is the Cloud account the worker manages; approvers must belong to it
+	AccountID string `json:"accountId"`
+}
+
+// Activities enforce the policy the worker was configured with, so workflow input cannot bring its own
+type Activities struct {
+	// Policy is the policy identities are checked against
+	Policy *Policy
+	// NoPolicy lets identities through when there is no Policy; without it they are rejected
+	NoPolicy bool
+	// CloudClient is the worker's Temporal Cloud client, whose account selects the approval rules (optional)
+	CloudClient cloudservicev1.CloudServiceClient
+}
+
+// CheckIdentities fails with a non-retryable PolicyViolation error listing every violation of the worker's policy
+func (a *Activities) CheckIdentities(ctx context.Context, identities *Identities) error {
+	if a.Policy == nil {
+		if a.NoPolicy {
+			return nil
+		}
+		return temporal.NewNonRetryableApplicationError(
+			"the operations worker has no policy, configure one with --policy or run the worker with --no-policy", "PolicyViolation", nil)
+	}
+	if violations := a.Policy.Check(identities); len(violations) > 0 {
+		return temporal.NewNonRetryableApplicationError(violations.Error(), "PolicyViolation", nil, violations)
+	}
+	return nil
+}
+
+// ResolveApproval returns the approval privileged operations need on the Cloud account the worker manages
+func (a *Activities) ResolveApproval(ctx context.Context) (*ApprovalRequirement, error) {
+	if a.CloudClient == nil {
+		return &ApprovalRequirement{}, nil
+	}
+	resp, err := a.CloudClient.GetAccount(ctx, &cloudservicev1.GetAccountRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	accountID := resp.GetAccount().GetId()
+	return &ApprovalRequirement{
+		Approval:  a.Policy.ApprovalFor(accountID),
+		AccountID: accountID,
+	}, nil
+}
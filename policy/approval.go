@@ -0,0 +1,69 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"temporal-jumpstart-operations/workflows/activities"
+)
+
+// privilegedAccountRoles are the account roles whose grant to a service account needs approval
+var privilegedAccountRoles = []string{"admin", "owner"}
+
+// ApprovalRules gate privileged operations on production Cloud accounts behind sign-off by other people
+type ApprovalRules struct {
+	// Accounts are the Temporal Cloud account IDs, or path.Match patterns, that count as production
+	Accounts []string `yaml:"accounts" json:"accounts,omitempty"`
+	// Approval is what a privileged operation on those profiles needs
+	Approval `yaml:",inline"`
+}
+
+// Approval is the sign-off a privileged operation needs before it runs
+type Approval struct {
+	// Approvers is how many distinct people other than the requester must approve (optional, defaults to 1)
+	Approvers int `yaml:"approvers" json:"approvers,omitempty"`
+	// Timeout is how long approvers have before the operation is rejected, e.g. 24h (optional, defaults to 24h)
+	Timeout string `yaml:"timeout" json:"timeout,omitempty"`
+	// AllowedApprovers restricts who may approve, by tcld login subject (optional, anyone but the requester when empty)
+	AllowedApprovers []string `yaml:"allowedApprovers" json:"allowedApprovers,omitempty"`
+}
+
+// ApprovalFor returns the approval privileged operations need on a Cloud account, or nil when the account is not production
+func (p *Policy) ApprovalFor(accountID string) *Approval {
+	if p == nil || accountID == "" {
+		return nil
+	}
+	for _, pattern := range p.Approvals.Accounts {
+		if matched, _ := path.Match(pattern, accountID); matched {
+			approval := p.Approvals.Approval
+			return &approval
+		}
+	}
+	return nil
+}
+
+// IsPrivilegedAccountRole reports whether granting the account role needs approval on production accounts
+func IsPrivilegedAccountRole(role string) bool {
+	return slices.ContainsFunc(privilegedAccountRoles, func(privileged string) bool { return strings.EqualFold(privileged, role) })
+}
+
+// Validate checks the approval count and timeout
+func (a *Approval) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if a.Approvers < 0 {
+		return fmt.Errorf("approvers must not be negative")
+	}
+	if a.Timeout != "" {
+		if timeout, err := activities.ParseDuration(a.Timeout); err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid approval timeout %q", a.Timeout)
+		}
+	}
+	if len(a.AllowedApprovers) > 0 && len(a.AllowedApprovers) < a.Approvers {
+		return fmt.Errorf("%d approvers are required but only %d are allowed", a.Approvers, len(a.AllowedApprovers))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalFor(t *testing.T) {
+	p := &Policy{Approvals: ApprovalRules{
+		Accounts: []string{"prod01", "prod-*"},
+		Approval: Approval{Approvers: 2, Timeout: "4h", AllowedApprovers: []string{"alice@example.com", "bob@example.com"}},
+	}}
+
+	tests := []struct {
+		name      string
+		policy    *Policy
+		accountID string
+		approval  bool
+	}{
+		{name: "listed account", policy: p, accountID: "prod01", approval: true},
+		{name: "account matching a pattern", policy: p, accountID: "prod-eu", approval: true},
+		{name: "other account", policy: p, accountID: "dev01"},
+		{name: "unknown account", policy: p, accountID: ""},
+		{name: "no approvals", policy: &Policy{}, accountID: "prod01"},
+		{name: "no policy", accountID: "prod01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approval := tt.policy.ApprovalFor(tt.accountID)
+			if !tt.approval {
+				require.Nil(t, approval)
+				return
+			}
+			require.Equal(t, &p.Approvals.Approval, approval)
+		})
+	}
+
+	// the returned approval is a copy
+	p.ApprovalFor("prod01").Approvers = 5
+	require.Equal(t, 2, p.Approvals.Approvers)
+}
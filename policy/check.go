@@ -0,0 +1,160 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"temporal-jumpstart-operations/workflows/activities"
+)
+
+// ServiceAccount is the part of a service account the policy inspects
+type ServiceAccount struct {
+	// Name is the service account name
+	Name string `json:"name"`
+	// Description is the description as given, before any generated default
+	Description string `json:"description,omitempty"`
+	// AccountRole is the account level role the service account is granted
+	AccountRole string `json:"accountRole,omitempty"`
+}
+
+// ApiKey is the part of a service account API key the policy inspects
+type ApiKey struct {
+	// ServiceAccountName is the owner of the key, which selects the team
+	ServiceAccountName string `json:"serviceAccountName"`
+	// Name is the API key display name
+	Name string `json:"name"`
+	// Description is the description as given, before any generated default
+	Description string `json:"description,omitempty"`
+	// Duration is the lifetime the key is created with
+	Duration string `json:"duration,omitempty"`
+}
+
+// Violation is a single broken rule
+type Violation struct {
+	// Subject is the service account or API key breaking the rule
+	Subject string `json:"subject"`
+	// Rule is the policy field that was broken, e.g. apiKeys.maxLifetime
+	Rule string `json:"rule"`
+	// Message explains the violation
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s (%s)", v.Subject, v.Message, v.Rule)
+}
+
+// Violations is the result of a policy check; it is an error when not empty
+type Violations []Violation
+
+func (v Violations) Error() string {
+	messages := make([]string, len(v))
+	for i, violation := range v {
+		messages[i] = violation.String()
+	}
+	return "policy violation: " + strings.Join(messages, "; ")
+}
+
+// Err returns the violations as an error, or nil when there are none
+func (v Violations) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+// CheckServiceAccount evaluates the service account rules and the team naming rules
+func (p *Policy) CheckServiceAccount(sa ServiceAccount) Violations {
+	if p == nil {
+		return nil
+	}
+	var violations Violations
+	subject := "service account " + sa.Name
+	add := func(rule, format string, args ...any) {
+		violations = append(violations, Violation{Subject: subject, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	rules := p.ServiceAccounts
+	if !matches(rules.NamePattern, sa.Name) {
+		add("serviceAccounts.namePattern", "name does not match %s", rules.NamePattern)
+	}
+	if len(p.Teams) > 0 {
+		team := p.TeamFor(sa.Name)
+		switch {
+		case team == nil:
+			add("teams.prefix", "name does not start with a team prefix (%s)", p.prefixes())
+		case !matches(team.NamePattern, sa.Name):
+			add("teams.namePattern", "name does not match %s required by team %s", team.NamePattern, team.Name)
+		}
+	}
+	if slices.ContainsFunc(rules.ForbiddenAccountRoles, func(role string) bool { return strings.EqualFold(role, sa.AccountRole) }) {
+		add("serviceAccounts.forbiddenAccountRoles", "account role %s is not allowed for service accounts", sa.AccountRole)
+	}
+	if rules.RequireDescription && strings.TrimSpace(sa.Description) == "" {
+		add("serviceAccounts.requireDescription", "a description is required")
+	} else if sa.Description != "" && !matches(rules.DescriptionPattern, sa.Description) {
+		add("serviceAccounts.descriptionPattern", "description does not match %s", rules.DescriptionPattern)
+	}
+	return violations
+}
+
+// CheckApiKey evaluates the API key rules, using the lifetime of the owner's team when it sets one
+func (p *Policy) CheckApiKey(key ApiKey) Violations {
+	if p == nil {
+		return nil
+	}
+	var violations Violations
+	subject := "api key " + key.Name
+	add := func(rule, format string, args ...any) {
+		violations = append(violations, Violation{Subject: subject, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	rules := p.ApiKeys
+	if !matches(rules.NamePattern, key.Name) {
+		add("apiKeys.namePattern", "name does not match %s", rules.NamePattern)
+	}
+
+	rule, maxLifetime := "apiKeys.maxLifetime", rules.MaxLifetime
+	if team := p.TeamFor(key.ServiceAccountName); team != nil && team.MaxKeyLifetime != "" {
+		rule, maxLifetime = "teams.maxKeyLifetime", team.MaxKeyLifetime
+	}
+	if maxLifetime != "" {
+		// An unparsable duration is reported by the caller's own validation
+		limit, _ := activities.ParseDuration(maxLifetime)
+		if lifetime, err := activities.ParseDuration(key.Duration); err == nil && lifetime > limit {
+			add(rule, "duration %s exceeds the maximum key lifetime of %s", key.Duration, maxLifetime)
+		}
+	}
+
+	if rules.RequireDescription && strings.TrimSpace(key.Description) == "" {
+		add("apiKeys.requireDescription", "a description is required")
+	} else if key.Description != "" && !matches(rules.DescriptionPattern, key.Description) {
+		add("apiKeys.descriptionPattern", "description does not match %s", rules.DescriptionPattern)
+	}
+	return violations
+}
+
+// matches reports whether value matches pattern; an empty or invalid pattern matches everything since Validate rejects invalid ones
+func matches(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return true
+	}
+	return re.MatchString(value)
+}
+
+// Check evaluates every service account and API key against the policy
+func (p *Policy) Check(identities *Identities) Violations {
+	var violations Violations
+	for _, sa := range identities.ServiceAccounts {
+		violations = append(violations, p.CheckServiceAccount(sa)...)
+	}
+	for _, key := range identities.ApiKeys {
+		violations = append(violations, p.CheckApiKey(key)...)
+	}
+	return violations
+}
@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckServiceAccount(t *testing.T) {
+	p := &Policy{
+		ServiceAccounts: ServiceAccountRules{
+			NamePattern:           "^[a-z-]+$",
+			ForbiddenAccountRoles: []string{"admin", "owner"},
+			RequireDescription:    true,
+			DescriptionPattern:    `OPS-\d+`,
+		},
+		Teams: []Team{
+			{Name: "billing", Prefix: "billing-"},
+			{Name: "billing-reports", Prefix: "billing-reports-", NamePattern: "^billing-reports-[a-z]+-worker$"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		sa    ServiceAccount
+		rules []string
+	}{
+		{
+			name: "compliant",
+			sa:   ServiceAccount{Name: "billing-worker", Description: "Billing worker OPS-12", AccountRole: "developer"},
+		},
+		{
+			name:  "name pattern",
+			sa:    ServiceAccount{Name: "billing-Worker2", Description: "OPS-12", AccountRole: "read"},
+			rules: []string{"serviceAccounts.namePattern"},
+		},
+		{
+			name:  "no team prefix",
+			sa:    ServiceAccount{Name: "orders-worker", Description: "OPS-12", AccountRole: "read"},
+			rules: []string{"teams.prefix"},
+		},
+		{
+			name:  "longest team prefix sets the name pattern",
+			sa:    ServiceAccount{Name: "billing-reports-daily", Description: "OPS-12", AccountRole: "read"},
+			rules: []string{"teams.namePattern"},
+		},
+		{
+			name:  "forbidden account role ignores case",
+			sa:    ServiceAccount{Name: "billing-worker", Description: "OPS-12", AccountRole: "Admin"},
+			rules: []string{"serviceAccounts.forbiddenAccountRoles"},
+		},
+		{
+			name:  "missing description",
+			sa:    ServiceAccount{Name: "billing-worker", Description: "  ", AccountRole: "read"},
+			rules: []string{"serviceAccounts.requireDescription"},
+		},
+		{
+			name:  "description pattern",
+			sa:    ServiceAccount{Name: "billing-worker", Description: "Billing worker", AccountRole: "read"},
+			rules: []string{"serviceAccounts.descriptionPattern"},
+		},
+		{
+			name:  "several violations",
+			sa:    ServiceAccount{Name: "Orders", AccountRole: "owner"},
+			rules: []string{"serviceAccounts.namePattern", "teams.prefix", "serviceAccounts.forbiddenAccountRoles", "serviceAccounts.requireDescription"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rules []string
+			for _, violation := range p.CheckServiceAccount(tt.sa) {
+				rules = append(rules, violation.Rule)
+			}
+			require.Equal(t, tt.rules, rules)
+		})
+	}
+
+	require.Nil(t, (*Policy)(nil).CheckServiceAccount(ServiceAccount{Name: "Anything", AccountRole: "owner"}))
+}
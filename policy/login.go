@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/cloud-sdk/cloudclient"
+)
+
+// LoginTokenIssuer issues the access tokens of 'tcld login'
+const LoginTokenIssuer = "https://login.tmprl.cloud/"
+
+// maxLoginTokenLifetime is the longest lifetime a login token has; API keys live far longer
+const maxLoginTokenLifetime = 24 * time.Hour
+
+// LoginToken holds the claims of a 'tcld login' access token
+type LoginToken struct {
+	Subject   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// ParseLoginToken reads the claims of a 'tcld login' access token without verifying its signature. It rejects API keys
+// and other tokens by issuer and lifetime, so people can only act as themselves and not through keys they minted.
+func ParseLoginToken(token string, now time.Time) (*LoginToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("not a 'tcld login' access token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("not a 'tcld login' access token: %w", err)
+	}
+	var claims struct {
+		Issuer    string `json:"iss"`
+		Subject   string `json:"sub"`
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("not a 'tcld login' access token: %w", err)
+	}
+	if strings.TrimSuffix(claims.Issuer, "/") != strings.TrimSuffix(LoginTokenIssuer, "/") {
+		return nil, fmt.Errorf("token issued by %q is not a 'tcld login' access token, API keys are not accepted", claims.Issuer)
+	}
+	if claims.Subject == "" || claims.IssuedAt == 0 || claims.ExpiresAt == 0 {
+		return nil, fmt.Errorf("'tcld login' access token lacks sub, iat or exp")
+	}
+	login := &LoginToken{
+		Subject:   claims.Subject,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	}
+	if login.ExpiresAt.Sub(login.IssuedAt) > maxLoginTokenLifetime {
+		return nil, fmt.Errorf("token of %s lives longer than a 'tcld login' access token, API keys are not accepted", login.Subject)
+	}
+	if !now.Before(login.ExpiresAt) {
+		return nil, fmt.Errorf("'tcld login' access token of %s expired at %s, run 'tcld login' again", login.Subject, login.ExpiresAt.Format(time.RFC3339))
+	}
+	return login, nil
+}
+
+// VerifyLoginToken returns the subject of a 'tcld login' access token and the Cloud account it belongs to.
+// Cloud checks the signature when it accepts the token, so the subject can be trusted afterwards.
+func VerifyLoginToken(ctx context.Context, token string) (subject string, accountID string, err error) {
+	login, err := ParseLoginToken(token, time.Now())
+	if err != nil {
+		return "", "", err
+	}
+	client, err := cloudclient.New(cloudclient.Options{APIKey: token})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create cloud client: %w", err)
+	}
+	defer client.Close()
+
+	resp, err := client.CloudService().GetAccount(ctx, &cloudservicev1.GetAccountRequest{})
+	if err != nil {
+		return "", "", fmt.Errorf("Temporal Cloud rejected the token of %s: %w", login.Subject, err)
+	}
+	return login.Subject, resp.GetAccount().GetId(), nil
+}
@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testToken(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2lnbmF0dXJl"
+}
+
+func TestParseLoginToken(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	issued, expires := now.Add(-time.Hour).Unix(), now.Add(time.Hour).Unix()
+
+	tests := []struct {
+		name    string
+		token   string
+		subject string
+		err     string
+	}{
+		{
+			name:    "login token",
+			token:   testToken(t, map[string]any{"iss": LoginTokenIssuer, "sub": "alice@example.com", "iat": issued, "exp": expires}),
+			subject: "alice@example.com",
+		},
+		{
+			name:    "issuer without trailing slash",
+			token:   testToken(t, map[string]any{"iss": "https://login.tmprl.cloud", "sub": "alice@example.com", "iat": issued, "exp": expires}),
+			subject: "alice@example.com",
+		},
+		{
+			name:  "api key issuer",
+			token: testToken(t, map[string]any{"iss": "https://saas-api.tmprl.cloud", "sub": "alice@example.com", "iat": issued, "exp": expires}),
+			err:   "API keys are not accepted",
+		},
+		{
+			name:  "long lived token",
+			token: testToken(t, map[string]any{"iss": LoginTokenIssuer, "sub": "alice@example.com", "iat": issued, "exp": now.Add(90 * 24 * time.Hour).Unix()}),
+			err:   "API keys are not accepted",
+		},
+		{
+			name:  "expired",
+			token: testToken(t, map[string]any{"iss": LoginTokenIssuer, "sub": "alice@example.com", "iat": issued, "exp": now.Add(-time.Minute).Unix()}),
+			err:   "expired",
+		},
+		{
+			name:  "no subject",
+			token: testToken(t, map[string]any{"iss": LoginTokenIssuer, "iat": issued, "exp": expires}),
+			err:   "lacks sub",
+		},
+		{
+			name:  "opaque key",
+			token: "tmprl_0123456789abcdef",
+			err:   "not a 'tcld login' access token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			login, err := ParseLoginToken(tt.token, now)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.subject, login.Subject)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package policy
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is the policy file name looked up in the state directory when no policy is given
+const DefaultFile = "policy.yaml"
+
+// Policy holds the organisation guardrails every service account and API key must satisfy before it reaches Cloud.
+// A nil Policy allows everything.
+type Policy struct {
+	// ServiceAccounts are the rules for every service account
+	ServiceAccounts ServiceAccountRules `yaml:"serviceAccounts" json:"serviceAccounts"`
+	// ApiKeys are the rules for every service account API key
+	ApiKeys ApiKeyRules `yaml:"apiKeys" json:"apiKeys"`
+	// Teams own service account name prefixes; when any are declared every service account must belong to one
+	Teams []Team `yaml:"teams" json:"teams"`
+	// Approvals gate privileged operations on production Cloud accounts (optional)
+	Approvals ApprovalRules `yaml:"approvals" json:"approvals"`
+}
+
+// ServiceAccountRules constrain service accounts
+type ServiceAccountRules struct {
+	// NamePattern is a regular expression every name must match (optional)
+	NamePattern string `yaml:"namePattern" json:"namePattern,omitempty"`
+	// ForbiddenAccountRoles are account roles a service account may not be granted, e.g. admin and owner (optional)
+	ForbiddenAccountRoles []string `yaml:"forbiddenAccountRoles" json:"forbiddenAccountRoles,omitempty"`
+	// RequireDescription rejects service accounts without an explicit description
+	RequireDescription bool `yaml:"requireDescription" json:"requireDescription,omitempty"`
+	// DescriptionPattern is a regular expression descriptions must match, e.g. a ticket reference (optional)
+	DescriptionPattern string `yaml:"descriptionPattern" json:"descriptionPattern,omitempty"`
+}
+
+// ApiKeyRules constrain service account API keys
+type ApiKeyRules struct {
+	// NamePattern is a regular expression every name must match (optional)
+	NamePattern string `yaml:"namePattern" json:"namePattern,omitempty"`
+	// MaxLifetime is the longest duration a key may be created with, e.g. 90d (optional)
+	MaxLifetime string `yaml:"maxLifetime" json:"maxLifetime,omitempty"`
+	// RequireDescription rejects keys without an explicit description
+	RequireDescription bool `yaml:"requireDescription" json:"requireDescription,omitempty"`
+	// DescriptionPattern is a regular expression descriptions must match (optional)
+	DescriptionPattern string `yaml:"descriptionPattern" json:"descriptionPattern,omitempty"`
+}
+
+// Team owns the service accounts whose names start with its prefix
+type Team struct {
+	// Name identifies the team in violations (required)
+	Name string `yaml:"name" json:"name"`
+	// Prefix every service account name of the team starts with (required)
+	Prefix string `yaml:"prefix" json:"prefix"`
+	// NamePattern is a regular expression the team's service account names must match (optional)
+	NamePattern string `yaml:"namePattern" json:"namePattern,omitempty"`
+	// MaxKeyLifetime overrides apiKeys.maxLifetime for the team's keys (optional)
+	MaxKeyLifetime string `yaml:"maxKeyLifetime" json:"maxKeyLifetime,omitempty"`
+}
+
+// Load reads and validates a policy file
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var p Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Validate checks that patterns compile, lifetimes parse and teams are well formed
+func (p *Policy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	// A slice keeps the reported error stable, which matters when the policy is validated inside a workflow
+	patterns := [][2]string{
+		{"serviceAccounts.namePattern", p.ServiceAccounts.NamePattern},
+		{"serviceAccounts.descriptionPattern", p.ServiceAccounts.DescriptionPattern},
+		{"apiKeys.namePattern", p.ApiKeys.NamePattern},
+		{"apiKeys.descriptionPattern", p.ApiKeys.DescriptionPattern},
+	}
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern[1]); err != nil {
+			return fmt.Errorf("%s: %w", pattern[0], err)
+		}
+	}
+	if p.ApiKeys.MaxLifetime != "" {
+		if _, err := activities.ParseDuration(p.ApiKeys.MaxLifetime); err != nil {
+			return fmt.Errorf("apiKeys.maxLifetime: %w", err)
+		}
+	}
+	for _, role := range p.ServiceAccounts.ForbiddenAccountRoles {
+		if _, err := activities.ParseAccountRole(role); err != nil {
+			return fmt.Errorf("serviceAccounts.forbiddenAccountRoles: %w", err)
+		}
+	}
+
+	for _, pattern := range p.Approvals.Accounts {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("approvals.accounts: %w", err)
+		}
+	}
+	if err := p.Approvals.Validate(); err != nil {
+		return fmt.Errorf("approvals: %w", err)
+	}
+
+	names := map[string]bool{}
+	for _, team := range p.Teams {
+		if team.Name == "" || team.Prefix == "" {
+			return fmt.Errorf("teams: name and prefix are required")
+		}
+		if names[team.Name] {
+			return fmt.Errorf("team %s is declared more than once", team.Name)
+		}
+		names[team.Name] = true
+		if _, err := regexp.Compile(team.NamePattern); err != nil {
+			return fmt.Errorf("team %s namePattern: %w", team.Name, err)
+		}
+		if team.MaxKeyLifetime != "" {
+			if _, err := activities.ParseDuration(team.MaxKeyLifetime); err != nil {
+				return fmt.Errorf("team %s maxKeyLifetime: %w", team.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// TeamFor returns the team owning a service account name, the one with the longest matching prefix
+func (p *Policy) TeamFor(serviceAccountName string) *Team {
+	if p == nil {
+		return nil
+	}
+	var owner *Team
+	for i := range p.Teams {
+		team := &p.Teams[i]
+		if strings.HasPrefix(serviceAccountName, team.Prefix) && (owner == nil || len(team.Prefix) > len(owner.Prefix)) {
+			owner = team
+		}
+	}
+	return owner
+}
+
+// prefixes lists the team prefixes for violation messages
+func (p *Policy) prefixes() string {
+	var prefixes []string
+	for _, team := range p.Teams {
+		prefixes = append(prefixes, team.Prefix)
+	}
+	return strings.Join(prefixes, ", ")
+}
@@ -0,0 +1,84 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultDotenvVariable is the variable DotenvSink writes the token to
+const DefaultDotenvVariable = "TEMPORAL_API_KEY"
+
+// DotenvSink sets Variable to the token in the dotenv file at Path, keeping every other line
+type DotenvSink struct {
+	Path     string
+	Variable string
+}
+
+// NewDotenvSink creates a DotenvSink writing variable, or TEMPORAL_API_KEY when empty
+func NewDotenvSink(path string, variable string) *DotenvSink {
+	if variable == "" {
+		variable = DefaultDotenvVariable
+	}
+	return &DotenvSink{Path: path, Variable: variable}
+}
+
+func (s *DotenvSink) Write(ctx context.Context, secret *Secret) (string, error) {
+	if err := checkVariable(s.Variable); err != nil {
+		return "", err
+	}
+	existing, err := os.ReadFile(s.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read dotenv file: %w", err)
+	}
+
+	assignment := s.Variable + "=" + dotenvValue(secret.Value)
+	var lines []string
+	if len(existing) > 0 {
+		lines = strings.Split(strings.TrimRight(string(existing), "\n"), "\n")
+	}
+	replaced := false
+	kept := lines[:0]
+	for _, line := range lines {
+		key, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(line), "export "), "=")
+		if strings.TrimSpace(key) == s.Variable {
+			if !replaced {
+				kept = append(kept, assignment)
+				replaced = true
+			}
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if !replaced {
+		kept = append(kept, assignment)
+	}
+
+	if _, err := writePrivateFile(s.Path, []byte(strings.Join(kept, "\n")+"\n")); err != nil {
+		return "", err
+	}
+	return s.Path + "#" + s.Variable, nil
+}
+
+// checkVariable fails unless the variable is a plain shell identifier, so it cannot add lines to the file
+func checkVariable(variable string) error {
+	if variable == "" {
+		return fmt.Errorf("dotenv variable is empty")
+	}
+	for i, r := range variable {
+		if r != '_' && !('A' <= r && r <= 'Z') && !('a' <= r && r <= 'z') && (i == 0 || !('0' <= r && r <= '9')) {
+			return fmt.Errorf("dotenv variable %q is not a valid identifier", variable)
+		}
+	}
+	return nil
+}
+
+// dotenvValue quotes values that dotenv parsers would otherwise split or truncate
+func dotenvValue(value string) string {
+	if strings.ContainsAny(value, " \t#\"'$\\") {
+		return strconv.Quote(value)
+	}
+	return value
+}
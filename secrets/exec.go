@@ -0,0 +1,38 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ExecSink pipes the token to the stdin of a shell command, e.g. a password manager CLI.
+// The command also gets TEMPORAL_API_KEY_NAME, TEMPORAL_API_KEY_ID and TEMPORAL_SERVICE_ACCOUNT_ID.
+type ExecSink struct {
+	Command string
+}
+
+// NewExecSink creates an ExecSink running command with sh -c
+func NewExecSink(command string) *ExecSink {
+	return &ExecSink{Command: command}
+}
+
+func (s *ExecSink) Write(ctx context.Context, secret *Secret) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", s.Command)
+	cmd.Stdin = strings.NewReader(secret.Value)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(),
+		"TEMPORAL_API_KEY_NAME="+secret.Name,
+		"TEMPORAL_API_KEY_ID="+secret.ApiKeyId,
+		"TEMPORAL_SERVICE_ACCOUNT_ID="+secret.ServiceAccountId,
+	)
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("secret command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return "exec: " + s.Command, nil
+}
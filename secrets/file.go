@@ -0,0 +1,181 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultPassphraseEnv is the environment variable holding the passphrase of encrypted key files
+const DefaultPassphraseEnv = "TEMPORAL_SECRET_PASSPHRASE"
+
+const (
+	passphraseHeader     = "temporal-jumpstart-passphrase-v1:"
+	passphraseIterations = 600_000
+	saltSize             = 16
+)
+
+// FileSink writes the token to {Dir}/{name}.key, readable only by the current user.
+// With an Envelope the token is encrypted to its recipients and written to {Dir}/{name}.key.age or .key.asc.
+type FileSink struct {
+	Dir      string
+	Envelope Envelope
+}
+
+// NewFileSink creates a FileSink writing to dir
+func NewFileSink(dir string) *FileSink {
+	return &FileSink{Dir: dir}
+}
+
+// File returns the file the named key is written to
+func (s *FileSink) File(name string) string {
+	if s.Envelope != nil {
+		return filepath.Join(s.Dir, name+".key"+s.Envelope.Extension())
+	}
+	return filepath.Join(s.Dir, name+".key")
+}
+
+func (s *FileSink) Write(ctx context.Context, secret *Secret) (string, error) {
+	content := []byte(secret.Value)
+	if s.Envelope != nil {
+		sealed, err := s.Envelope.Seal(content)
+		if err != nil {
+			return "", err
+		}
+		content = sealed
+	}
+	name := secretName(secret)
+	if err := CheckName(name); err != nil {
+		return "", err
+	}
+	return writePrivateFile(s.File(name), content)
+}
+
+// EncryptedFileSink writes the token to {Dir}/{name}.key.enc, encrypted with AES-256-GCM
+// under a key derived from the passphrase in PassphraseEnv
+type EncryptedFileSink struct {
+	Dir           string
+	PassphraseEnv string
+}
+
+// NewEncryptedFileSink creates an EncryptedFileSink reading its passphrase from passphraseEnv,
+// or TEMPORAL_SECRET_PASSPHRASE when empty
+func NewEncryptedFileSink(dir string, passphraseEnv string) *EncryptedFileSink {
+	if passphraseEnv == "" {
+		passphraseEnv = DefaultPassphraseEnv
+	}
+	return &EncryptedFileSink{Dir: dir, PassphraseEnv: passphraseEnv}
+}
+
+// File returns the file the named key is written to
+func (s *EncryptedFileSink) File(name string) string {
+	return filepath.Join(s.Dir, name+".key.enc")
+}
+
+func (s *EncryptedFileSink) Write(ctx context.Context, secret *Secret) (string, error) {
+	name := secretName(secret)
+	if err := CheckName(name); err != nil {
+		return "", err
+	}
+	passphrase := os.Getenv(s.PassphraseEnv)
+	if passphrase == "" {
+		return "", fmt.Errorf("%s is not set", s.PassphraseEnv)
+	}
+	sealed, err := SealWithPassphrase(passphrase, []byte(secret.Value))
+	if err != nil {
+		return "", err
+	}
+	return writePrivateFile(s.File(name), sealed)
+}
+
+// SealWithPassphrase encrypts plaintext with a PBKDF2 derived AES-256-GCM key.
+// The result is a single text line safe to copy around.
+func SealWithPassphrase(passphrase string, plaintext []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	aead, err := passphraseAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := append(append(salt, nonce...), aead.Seal(nil, nonce, plaintext, nil)...)
+	return []byte(passphraseHeader + base64.StdEncoding.EncodeToString(sealed) + "\n"), nil
+}
+
+// OpenWithPassphrase decrypts data produced by SealWithPassphrase
+func OpenWithPassphrase(passphrase string, data []byte) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(strings.TrimSpace(string(data)), passphraseHeader)
+	if !ok {
+		return nil, errors.New("not a passphrase encrypted key file")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted key file: %w", err)
+	}
+	if len(sealed) < saltSize {
+		return nil, errors.New("invalid encrypted key file: too short")
+	}
+	aead, err := passphraseAEAD(passphrase, sealed[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	sealed = sealed[saltSize:]
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("invalid encrypted key file: too short")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt key file: wrong passphrase or corrupted file")
+	}
+	return plaintext, nil
+}
+
+func passphraseAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, passphraseIterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// writePrivateFile writes content to file with mode 0600, creating its directory.
+// An existing file is restricted to 0600 before the content is written to it.
+func writePrivateFile(file string, content []byte) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return "", fmt.Errorf("failed to create output path: %w", err)
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed to write api key file: %w", err)
+	}
+	defer f.Close()
+	if err := f.Chmod(0o600); err != nil {
+		return "", fmt.Errorf("failed to restrict api key file: %w", err)
+	}
+	if _, err := f.Write(content); err != nil {
+		return "", fmt.Errorf("failed to write api key file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write api key file: %w", err)
+	}
+	return file, nil
+}
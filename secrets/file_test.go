@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSinkRejectsNamesOutsideItsDir(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewFileSink(filepath.Join(dir, "keys"))
+
+	for _, name := range []string{"../escaped", "nested/key", `..\escaped`, "..", "."} {
+		_, err := sink.Write(context.Background(), &Secret{Name: name, Value: "token"})
+		require.Error(t, err, name)
+	}
+	_, err := NewEncryptedFileSink(dir, "").Write(context.Background(), &Secret{Name: "../escaped", Value: "token"})
+	require.Error(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestFileSinkRestrictsExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "ops.key")
+	require.NoError(t, os.WriteFile(file, []byte("old"), 0o644))
+
+	written, err := NewFileSink(dir).Write(context.Background(), &Secret{Name: "ops", Value: "token"})
+	require.NoError(t, err)
+	require.Equal(t, file, written)
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "token", string(content))
+}
+
+func TestDotenvVariable(t *testing.T) {
+	for _, uri := range []string{
+		"dotenv:///tmp/.env?var=TEMPORAL_API_KEY",
+		"dotenv:///tmp/.env?var=_key1",
+		"dotenv:///tmp/.env",
+	} {
+		_, err := ParseSink(uri)
+		require.NoError(t, err, uri)
+	}
+	for _, uri := range []string{
+		"dotenv:///tmp/.env?var=1KEY",
+		"dotenv:///tmp/.env?var=KEY%0AOTHER",
+		"dotenv:///tmp/.env?var=A=B",
+	} {
+		_, err := ParseSink(uri)
+		require.Error(t, err, uri)
+	}
+}
@@ -0,0 +1,58 @@
+package secrets
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SinkPolicy restricts the sinks a worker writes secrets to. Output paths arrive in workflow input,
+// so a worker shared by several callers should not run their commands or write wherever they ask.
+type SinkPolicy struct {
+	// AllowExec allows exec:// sinks, which run a shell command on the worker
+	AllowExec bool
+	// Dirs are the directories file, encfile and dotenv sinks and generated config files may be written under;
+	// none rejects every local path
+	Dirs []string
+}
+
+// CheckSink fails when the policy does not allow the sink; a nil policy allows every sink
+func (p *SinkPolicy) CheckSink(sink SecretSink) error {
+	if p == nil {
+		return nil
+	}
+	switch s := sink.(type) {
+	case *ExecSink:
+		if !p.AllowExec {
+			return fmt.Errorf("exec output paths are not allowed on this worker")
+		}
+	case *FileSink:
+		return p.CheckDir(s.Dir)
+	case *EncryptedFileSink:
+		return p.CheckDir(s.Dir)
+	case *DotenvSink:
+		return p.CheckDir(filepath.Dir(s.Path))
+	}
+	return nil
+}
+
+// CheckDir fails when the policy does not allow writing under dir; a nil policy allows every directory
+func (p *SinkPolicy) CheckDir(dir string) error {
+	if p == nil {
+		return nil
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	for _, allowed := range p.Dirs {
+		allowedAbs, err := filepath.Abs(allowed)
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(allowedAbs, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("output directory %s is not allowed on this worker", dir)
+}
@@ -0,0 +1,42 @@
+package secrets
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkPolicy(t *testing.T) {
+	dir := t.TempDir()
+	policy := &SinkPolicy{Dirs: []string{dir}}
+
+	for _, uri := range []string{
+		dir,
+		"file://" + filepath.Join(dir, "keys"),
+		"encfile://" + filepath.Join(dir, "keys") + "?passphrase-env=PASS",
+		"dotenv://" + filepath.Join(dir, ".env"),
+		"vault+https://vault.example.com/secret",
+	} {
+		sink, err := ParseSink(uri)
+		require.NoError(t, err)
+		require.NoError(t, policy.CheckSink(sink), uri)
+	}
+
+	for _, uri := range []string{
+		filepath.Dir(dir),
+		"file://" + filepath.Join(dir, "..", "elsewhere"),
+		"dotenv:///etc/profile.d/temporal.env",
+		"exec://cat > /tmp/key",
+	} {
+		sink, err := ParseSink(uri)
+		require.NoError(t, err)
+		require.Error(t, policy.CheckSink(sink), uri)
+	}
+
+	exec, err := ParseSink("exec://pass insert -m temporal")
+	require.NoError(t, err)
+	require.NoError(t, (&SinkPolicy{AllowExec: true}).CheckSink(exec))
+	require.NoError(t, (*SinkPolicy)(nil).CheckSink(exec))
+	require.Error(t, (&SinkPolicy{}).CheckDir(dir))
+}
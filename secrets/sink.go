@@ -0,0 +1,145 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	SchemeFile          = "file"
+	SchemeEncryptedFile = "encfile"
+	SchemeDotenv        = "dotenv"
+	SchemeVaultHTTP     = "vault+http"
+	SchemeVaultHTTPS    = "vault+https"
+	SchemeExec          = "exec"
+)
+
+// Secret is an API key token and the identifiers needed to file it
+type Secret struct {
+	// Name is the API key name
+	Name string
+	// ApiKeyId is the Temporal Cloud API key ID
+	ApiKeyId string
+	// ServiceAccountId is the service account owning the key
+	ServiceAccountId string
+	// Value is the API key token
+	Value string
+}
+
+// SecretSink stores API key tokens somewhere other than workflow history
+type SecretSink interface {
+	// Write stores the secret and returns where it was written; the location never contains the secret
+	Write(ctx context.Context, secret *Secret) (string, error)
+}
+
+// ParseSink returns the sink for an output path. The sink is selected by URI scheme:
+//
+//	/path/to/dir or file:///path/to/dir          plain {dir}/{name}.key
+//	encfile:///path/to/dir?passphrase-env=VAR    passphrase encrypted {dir}/{name}.key.enc
+//	dotenv:///path/to/.env?var=TEMPORAL_API_KEY  VAR=token line in a dotenv file
+//	vault+https://host:8200/{mount}/{path}       Vault KV v2 secret at {mount}/{path}/{name}
+//	exec://command args                          token piped to the stdin of 'sh -c command args'
+//
+// File sinks can also encrypt to age or OpenPGP recipients, see WithRecipients.
+func ParseSink(uri string) (SecretSink, error) {
+	scheme, rest, ok := strings.Cut(uri, "://")
+	if !ok {
+		return NewFileSink(uri), nil
+	}
+
+	switch scheme {
+	case SchemeFile:
+		path, _ := splitQuery(rest)
+		return NewFileSink(path), nil
+	case SchemeEncryptedFile:
+		path, query := splitQuery(rest)
+		return NewEncryptedFileSink(path, query.Get("passphrase-env")), nil
+	case SchemeDotenv:
+		path, query := splitQuery(rest)
+		sink := NewDotenvSink(path, query.Get("var"))
+		if err := checkVariable(sink.Variable); err != nil {
+			return nil, err
+		}
+		return sink, nil
+	case SchemeVaultHTTP, SchemeVaultHTTPS:
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vault output path: %w", err)
+		}
+		return NewVaultSink(u)
+	case SchemeExec:
+		if strings.TrimSpace(rest) == "" {
+			return nil, fmt.Errorf("exec output path needs a command")
+		}
+		return NewExecSink(rest), nil
+	}
+	return nil, fmt.Errorf("unknown output path scheme %q", scheme)
+}
+
+// LocalDir returns the local directory next to the secret where generated config files belong,
+// or an empty string when the sink is not on the local filesystem
+func LocalDir(uri string) string {
+	sink, err := ParseSink(uri)
+	if err != nil {
+		return ""
+	}
+	switch s := sink.(type) {
+	case *FileSink:
+		return s.Dir
+	case *EncryptedFileSink:
+		return s.Dir
+	case *DotenvSink:
+		return filepath.Dir(s.Path)
+	}
+	return ""
+}
+
+// KeyFile returns the plaintext key file the sink writes the named API key to,
+// or an empty string when the sink does not write one
+func KeyFile(uri string, name string) string {
+	sink, err := ParseSink(uri)
+	if err != nil {
+		return ""
+	}
+	if s, ok := sink.(*FileSink); ok {
+		return s.File(name)
+	}
+	return ""
+}
+
+// Encrypts reports whether the sink only ever writes the token encrypted
+func Encrypts(sink SecretSink) bool {
+	switch s := sink.(type) {
+	case *EncryptedFileSink:
+		return true
+	case *FileSink:
+		return s.Envelope != nil
+	}
+	return false
+}
+
+// splitQuery splits a scheme-less URI remainder into its path and query parameters
+func splitQuery(rest string) (string, url.Values) {
+	path, rawQuery, _ := strings.Cut(rest, "?")
+	query, _ := url.ParseQuery(rawQuery)
+	return path, query
+}
+
+// CheckName fails when an API key name cannot be used as a file name, since file sinks join it to their directory
+func CheckName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\\x00") {
+		return fmt.Errorf("api key name %q cannot be used as a file name", name)
+	}
+	return nil
+}
+
+// secretName returns the name a secret is filed under
+func secretName(secret *Secret) string {
+	if secret.Name != "" {
+		return secret.Name
+	}
+	return secret.ApiKeyId
+}
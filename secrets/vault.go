@@ -0,0 +1,118 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultVaultTokenEnv is the environment variable holding the Vault token
+const DefaultVaultTokenEnv = "VAULT_TOKEN"
+
+// VaultSink writes the token to a KV v2 secrets engine at {Mount}/{Path}/{name}.
+// Any store speaking the Vault KV v2 HTTP API works, including a local stub for tests.
+type VaultSink struct {
+	// Address is the base URL of the store, e.g. https://vault.example.com:8200
+	Address string
+	// Mount is the KV v2 mount, e.g. secret
+	Mount string
+	// Path is the path below the mount the key is written under (optional)
+	Path string
+	// Token authenticates the request as X-Vault-Token
+	Token string
+	// TokenEnv is the environment variable Token was read from (optional, defaults to VAULT_TOKEN)
+	TokenEnv string
+	// Namespace is the Vault Enterprise namespace (optional)
+	Namespace string
+	// Client sends the request (optional)
+	Client *http.Client
+}
+
+// NewVaultSink creates a VaultSink from vault+http(s)://host:port/{mount}/{path}?token-env=VAR&namespace=ns.
+// The token is read from VAULT_TOKEN unless token-env names another variable.
+func NewVaultSink(u *url.URL) (*VaultSink, error) {
+	mount, path, _ := strings.Cut(strings.Trim(u.Path, "/"), "/")
+	if mount == "" {
+		return nil, fmt.Errorf("vault output path needs a mount, e.g. %s://%s/secret/temporal", u.Scheme, u.Host)
+	}
+	tokenEnv := u.Query().Get("token-env")
+	if tokenEnv == "" {
+		tokenEnv = DefaultVaultTokenEnv
+	}
+	namespace := u.Query().Get("namespace")
+	if namespace == "" {
+		namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+	return &VaultSink{
+		Address:   strings.TrimPrefix(u.Scheme, "vault+") + "://" + u.Host,
+		Mount:     mount,
+		Path:      path,
+		Token:     os.Getenv(tokenEnv),
+		TokenEnv:  tokenEnv,
+		Namespace: namespace,
+		Client:    &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// SecretPath returns the path below the mount the named key is written to
+func (s *VaultSink) SecretPath(name string) string {
+	if s.Path == "" {
+		return name
+	}
+	return s.Path + "/" + name
+}
+
+func (s *VaultSink) Write(ctx context.Context, secret *Secret) (string, error) {
+	if s.Token == "" {
+		tokenEnv := s.TokenEnv
+		if tokenEnv == "" {
+			tokenEnv = DefaultVaultTokenEnv
+		}
+		return "", fmt.Errorf("no vault token, set %s", tokenEnv)
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{
+			"api_key":            secret.Value,
+			"api_key_id":         secret.ApiKeyId,
+			"api_key_name":       secret.Name,
+			"service_account_id": secret.ServiceAccountId,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	path := s.SecretPath(secretName(secret))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/v1/%s/data/%s", s.Address, s.Mount, path), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", s.Token)
+	if s.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.Namespace)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to write secret to vault: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("failed to write secret to vault: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return s.Mount + "/" + path, nil
+}
@@ -0,0 +1,112 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVaultSink(t *testing.T, handler http.HandlerFunc, rawURL string) *VaultSink {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	u.Host = server.Listener.Addr().String()
+	sink, err := NewVaultSink(u)
+	require.NoError(t, err)
+	return sink
+}
+
+func TestVaultSinkWrite(t *testing.T) {
+	t.Setenv("OPS_VAULT_TOKEN", "s.token")
+	t.Setenv("VAULT_NAMESPACE", "")
+
+	var request struct {
+		Method    string
+		Path      string
+		Token     string
+		Namespace string
+		Body      struct {
+			Data map[string]string `json:"data"`
+		}
+	}
+	sink := newTestVaultSink(t, func(w http.ResponseWriter, r *http.Request) {
+		request.Method = r.Method
+		request.Path = r.URL.Path
+		request.Token = r.Header.Get("X-Vault-Token")
+		request.Namespace = r.Header.Get("X-Vault-Namespace")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request.Body))
+		w.WriteHeader(http.StatusOK)
+	}, "vault+http://vault/secret/temporal/ops?token-env=OPS_VAULT_TOKEN&namespace=platform")
+
+	location, err := sink.Write(context.Background(), &Secret{
+		Name:             "billing-worker",
+		ApiKeyId:         "key-1",
+		ServiceAccountId: "sa-1",
+		Value:            "tok",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "secret/temporal/ops/billing-worker", location)
+
+	require.Equal(t, http.MethodPost, request.Method)
+	require.Equal(t, "/v1/secret/data/temporal/ops/billing-worker", request.Path)
+	require.Equal(t, "s.token", request.Token)
+	require.Equal(t, "platform", request.Namespace)
+	require.Equal(t, map[string]string{
+		"api_key":            "tok",
+		"api_key_id":         "key-1",
+		"api_key_name":       "billing-worker",
+		"service_account_id": "sa-1",
+	}, request.Body.Data)
+}
+
+func TestVaultSinkWriteWithoutPathUsesKeyId(t *testing.T) {
+	t.Setenv("VAULT_TOKEN", "s.token")
+
+	var path string
+	sink := newTestVaultSink(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}, "vault+http://vault/kv")
+
+	location, err := sink.Write(context.Background(), &Secret{ApiKeyId: "key-1", Value: "tok"})
+	require.NoError(t, err)
+	require.Equal(t, "kv/key-1", location)
+	require.Equal(t, "/v1/kv/data/key-1", path)
+}
+
+func TestVaultSinkWriteFailure(t *testing.T) {
+	t.Setenv("VAULT_TOKEN", "s.token")
+
+	sink := newTestVaultSink(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+	}, "vault+http://vault/secret")
+
+	_, err := sink.Write(context.Background(), &Secret{Name: "billing-worker", Value: "tok"})
+	require.ErrorContains(t, err, "403 Forbidden")
+	require.ErrorContains(t, err, "permission denied")
+}
+
+func TestVaultSinkMissingTokenNamesTokenEnv(t *testing.T) {
+	t.Setenv("OPS_VAULT_TOKEN", "")
+
+	sink := newTestVaultSink(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request is expected without a token")
+	}, "vault+http://vault/secret?token-env=OPS_VAULT_TOKEN")
+
+	_, err := sink.Write(context.Background(), &Secret{Name: "billing-worker", Value: "tok"})
+	require.EqualError(t, err, "no vault token, set OPS_VAULT_TOKEN")
+}
+
+func TestNewVaultSinkRequiresMount(t *testing.T) {
+	u, err := url.Parse("vault+https://vault.example.com:8200/")
+	require.NoError(t, err)
+	_, err = NewVaultSink(u)
+	require.ErrorContains(t, err, "needs a mount")
+}
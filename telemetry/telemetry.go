@@ -0,0 +1,140 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.temporal.io/sdk/client"
+	temporalotel "go.temporal.io/sdk/contrib/opentelemetry"
+	"go.temporal.io/sdk/interceptor"
+	"google.golang.org/grpc"
+)
+
+// DefaultServiceName is the service.name resource attribute when none is configured
+const DefaultServiceName = "temporal-jumpstart-operations"
+
+// Options configures the OTLP export of metrics and traces
+type Options struct {
+	// Endpoint is the OTLP gRPC collector host:port (optional, defaults to OTEL_EXPORTER_OTLP_ENDPOINT)
+	Endpoint string
+	// Insecure disables TLS to the collector, e.g. for a local agent (optional)
+	Insecure bool
+	// ServiceName is the service.name resource attribute (optional, defaults to OTEL_SERVICE_NAME or temporal-jumpstart-operations)
+	ServiceName string
+	// MetricsInterval is how often metrics are exported (optional, defaults to 10s)
+	MetricsInterval time.Duration
+}
+
+// Enabled reports whether a collector endpoint is configured
+func (o *Options) Enabled() bool {
+	return o.Endpoint != "" || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != ""
+}
+
+// Provider exports Temporal SDK metrics, workflow traces and Cloud API gRPC telemetry over OTLP
+type Provider struct {
+	meterProvider  *sdkmetric.MeterProvider
+	tracerProvider *sdktrace.TracerProvider
+	tracing        interceptor.Interceptor
+}
+
+// Setup creates the OTLP exporters and installs them as the global OpenTelemetry providers
+func Setup(ctx context.Context, options Options) (*Provider, error) {
+	serviceName := options.ServiceName
+	if serviceName == "" {
+		serviceName = os.Getenv("OTEL_SERVICE_NAME")
+	}
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	if options.MetricsInterval == 0 {
+		options.MetricsInterval = 10 * time.Second
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build telemetry resource: %w", err)
+	}
+
+	var metricOptions []otlpmetricgrpc.Option
+	var traceOptions []otlptracegrpc.Option
+	if options.Endpoint != "" {
+		metricOptions = append(metricOptions, otlpmetricgrpc.WithEndpoint(options.Endpoint))
+		traceOptions = append(traceOptions, otlptracegrpc.WithEndpoint(options.Endpoint))
+	}
+	if options.Insecure {
+		metricOptions = append(metricOptions, otlpmetricgrpc.WithInsecure())
+		traceOptions = append(traceOptions, otlptracegrpc.WithInsecure())
+	}
+
+	metricExporter, err := otlpmetricgrpc.New(ctx, metricOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+	traceExporter, err := otlptracegrpc.New(ctx, traceOptions...)
+	if err != nil {
+		metricExporter.Shutdown(ctx)
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	p := &Provider{
+		meterProvider: sdkmetric.NewMeterProvider(
+			sdkmetric.WithResource(res),
+			sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(options.MetricsInterval))),
+		),
+		tracerProvider: sdktrace.NewTracerProvider(
+			sdktrace.WithResource(res),
+			sdktrace.WithBatcher(traceExporter),
+		),
+	}
+	otel.SetMeterProvider(p.meterProvider)
+	otel.SetTracerProvider(p.tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if p.tracing, err = temporalotel.NewTracingInterceptor(temporalotel.TracerOptions{
+		Tracer: p.tracerProvider.Tracer("temporal-sdk-go"),
+	}); err != nil {
+		p.Shutdown(ctx)
+		return nil, fmt.Errorf("failed to create tracing interceptor: %w", err)
+	}
+	return p, nil
+}
+
+// MetricsHandler returns the Temporal SDK metrics handler; the SDK metric names match the bundled dashboard
+func (p *Provider) MetricsHandler() client.MetricsHandler {
+	return temporalotel.NewMetricsHandler(temporalotel.MetricsHandlerOptions{
+		Meter: p.meterProvider.Meter("temporal-sdk-go"),
+	})
+}
+
+// TracingInterceptor returns the Temporal tracing interceptor. Set on a client it also traces
+// the workflows and activities of workers created from that client.
+func (p *Provider) TracingInterceptor() interceptor.Interceptor {
+	return p.tracing
+}
+
+// GRPCDialOptions returns dial options recording client metrics and spans for every gRPC call
+func (p *Provider) GRPCDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithMeterProvider(p.meterProvider),
+			otelgrpc.WithTracerProvider(p.tracerProvider),
+		)),
+	}
+}
+
+// Shutdown flushes and stops the exporters
+func (p *Provider) Shutdown(ctx context.Context) error {
+	return errors.Join(p.tracerProvider.Shutdown(ctx), p.meterProvider.Shutdown(ctx))
+}
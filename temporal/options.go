@@ -0,0 +1,145 @@
+package temporal
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/testsuite"
+)
+
+// DefaultNamespace is the namespace the dev server registers and the client connects to by default
+const DefaultNamespace = "default"
+
+// SearchAttributeTypes are the search attribute types the dev server can pre-register
+var SearchAttributeTypes = []string{"Keyword", "Text", "Int", "Double", "Bool", "Datetime", "KeywordList"}
+
+// LogLevels are the dev server log levels
+var LogLevels = []string{"debug", "info", "warn", "error", "never"}
+
+// TemporalServiceOptions configures the Temporal dev server started by a TemporalService
+type TemporalServiceOptions struct {
+	// Port is the frontend port (optional, a free port is picked when 0)
+	Port int
+	// EnableUI starts the Web UI (optional, headless by default)
+	EnableUI bool
+	// UIPort is the Web UI port (optional, a free port is picked when 0)
+	UIPort int
+	// DBFilename persists the server state in a SQLite file (optional, in-memory when empty)
+	DBFilename string
+	// Namespace is the namespace the client connects to (optional, defaults to 'default')
+	Namespace string
+	// ExtraNamespaces are registered on startup in addition to Namespace (optional)
+	ExtraNamespaces []string
+	// DynamicConfigValues are dynamic config overrides, e.g. system.enableNexus: true (optional)
+	DynamicConfigValues map[string]string
+	// SearchAttributes maps custom search attribute names to their type, e.g. CustomerId: Keyword (optional)
+	SearchAttributes map[string]string
+	// LogLevel is the server log level (optional, defaults to 'warn')
+	LogLevel string
+	// CLIVersion pins the downloaded Temporal CLI version, e.g. v1.3.0 (optional, defaults to the SDK default)
+	CLIVersion string
+	// CLIPath is an existing Temporal CLI binary to run instead of downloading one (optional)
+	CLIPath string
+	// MetricsHandler receives the SDK client and worker metrics (optional)
+	MetricsHandler client.MetricsHandler
+	// Interceptors are client interceptors; those that are also worker interceptors apply to workers too (optional)
+	Interceptors []interceptor.ClientInterceptor
+}
+
+// Validate checks the options are consistent
+func (o *TemporalServiceOptions) Validate() error {
+	if o.Port < 0 || o.Port > 65535 {
+		return fmt.Errorf("invalid port %d", o.Port)
+	}
+	if o.UIPort < 0 || o.UIPort > 65535 {
+		return fmt.Errorf("invalid UI port %d", o.UIPort)
+	}
+	if o.UIPort != 0 && !o.EnableUI {
+		return fmt.Errorf("a UI port requires the UI to be enabled")
+	}
+	if o.LogLevel != "" && !slices.Contains(LogLevels, o.LogLevel) {
+		return fmt.Errorf("invalid log level %q, expected one of %v", o.LogLevel, LogLevels)
+	}
+	if o.CLIVersion != "" && o.CLIPath != "" {
+		return fmt.Errorf("choose either a CLI version or a CLI path")
+	}
+	for name, t := range o.SearchAttributes {
+		if !slices.Contains(SearchAttributeTypes, t) {
+			return fmt.Errorf("invalid type %q for search attribute %s, expected one of %v", t, name, SearchAttributeTypes)
+		}
+	}
+	return nil
+}
+
+// devServerOptions converts the options into the SDK dev server options
+func (o *TemporalServiceOptions) devServerOptions(port int) testsuite.DevServerOptions {
+	namespace := o.namespace()
+
+	var extraArgs []string
+	for _, ns := range o.ExtraNamespaces {
+		if ns != namespace {
+			extraArgs = append(extraArgs, "--namespace", ns)
+		}
+	}
+	for _, key := range sortedKeys(o.DynamicConfigValues) {
+		extraArgs = append(extraArgs, "--dynamic-config-value", key+"="+o.DynamicConfigValues[key])
+	}
+	for _, name := range sortedKeys(o.SearchAttributes) {
+		extraArgs = append(extraArgs, "--search-attribute", name+"="+o.SearchAttributes[name])
+	}
+
+	options := testsuite.DevServerOptions{
+		ExistingPath: o.CLIPath,
+		CachedDownload: testsuite.CachedDownload{
+			Version: o.CLIVersion,
+		},
+		ClientOptions: &client.Options{
+			HostPort:       fmt.Sprintf("localhost:%d", port),
+			Namespace:      namespace,
+			MetricsHandler: o.MetricsHandler,
+			Interceptors:   o.Interceptors,
+		},
+		DBFilename: o.DBFilename,
+		EnableUI:   o.EnableUI,
+		LogLevel:   o.LogLevel,
+		ExtraArgs:  extraArgs,
+	}
+	if o.UIPort != 0 {
+		options.UIPort = fmt.Sprint(o.UIPort)
+	}
+	return options
+}
+
+// namespace returns the namespace the client connects to
+func (o *TemporalServiceOptions) namespace() string {
+	if o.Namespace == "" {
+		return DefaultNamespace
+	}
+	return o.Namespace
+}
+
+// ParseKeyValues parses key=value pairs, e.g. from repeated CLI flags
+func ParseKeyValues(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid value %q, expected key=value", pair)
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return values, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,175 @@
+package temporal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultStateDirName is the directory below the user's home holding the persistent server state
+	DefaultStateDirName = ".temporal-jumpstart-operations"
+
+	serverPidFile  = "server.pid"
+	serverInfoFile = "server.json"
+	serverDBFile   = "operations.db"
+	serverLogFile  = "server.log"
+)
+
+// ErrServerNotRunning is returned when no persistent TemporalService is running
+var ErrServerNotRunning = errors.New("no persistent TemporalService is running")
+
+// ServerInfo describes a running persistent TemporalService so other processes can find it
+type ServerInfo struct {
+	Pid        int       `json:"pid"`
+	Address    string    `json:"address"`
+	Namespace  string    `json:"namespace"`
+	UIAddress  string    `json:"uiAddress,omitempty"`
+	DBFilename string    `json:"dbFilename"`
+	StartedAt  time.Time `json:"startedAt"`
+}
+
+// DefaultStateDir returns ~/.temporal-jumpstart-operations
+func DefaultStateDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return DefaultStateDirName
+	}
+	return filepath.Join(home, DefaultStateDirName)
+}
+
+// ServerDBFile returns the SQLite file a persistent TemporalService keeps its state in
+func ServerDBFile(stateDir string) string {
+	return filepath.Join(stateDir, serverDBFile)
+}
+
+// ServerLogFile returns the file a detached persistent TemporalService logs to
+func ServerLogFile(stateDir string) string {
+	return filepath.Join(stateDir, serverLogFile)
+}
+
+// AcquireServerLock creates the pidfile for the current process. It fails when another live process holds it
+// and takes over the pidfile of a process that is gone. The returned func releases the lock.
+func AcquireServerLock(stateDir string) (func(), error) {
+	if err := os.MkdirAll(stateDir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create state dir: %w", err)
+	}
+	pidFile := filepath.Join(stateDir, serverPidFile)
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(pidFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return func() {
+				os.Remove(filepath.Join(stateDir, serverInfoFile))
+				os.Remove(pidFile)
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create pidfile: %w", err)
+		}
+		if pid, err := readPid(pidFile); err == nil && processAlive(pid) {
+			return nil, fmt.Errorf("a persistent TemporalService is already running (pid %d)", pid)
+		}
+		// stale pidfile left by a crashed server
+		os.Remove(pidFile)
+	}
+	return nil, fmt.Errorf("failed to acquire %s", pidFile)
+}
+
+// WriteServerInfo records the running server for discovery
+func WriteServerInfo(stateDir string, info *ServerInfo) error {
+	content, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(stateDir, serverInfoFile), content, 0o600)
+}
+
+// ReadServerInfo returns the running persistent server, or ErrServerNotRunning when there is none
+func ReadServerInfo(stateDir string) (*ServerInfo, error) {
+	pid, err := readPid(filepath.Join(stateDir, serverPidFile))
+	if err != nil || !processAlive(pid) {
+		return nil, ErrServerNotRunning
+	}
+	content, err := os.ReadFile(filepath.Join(stateDir, serverInfoFile))
+	if os.IsNotExist(err) {
+		// the server holds the lock but is still starting up
+		return nil, ErrServerNotRunning
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server info: %w", err)
+	}
+	var info ServerInfo
+	if err := json.Unmarshal(content, &info); err != nil {
+		return nil, fmt.Errorf("invalid server info: %w", err)
+	}
+	if info.Pid != pid {
+		return nil, ErrServerNotRunning
+	}
+	return &info, nil
+}
+
+// StopServer signals the persistent server to shut down and waits for it to release its lock
+func StopServer(stateDir string, timeout time.Duration) (*ServerInfo, error) {
+	info, err := ReadServerInfo(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	process, err := os.FindProcess(info.Pid)
+	if err != nil {
+		return nil, err
+	}
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		return nil, fmt.Errorf("failed to signal server (pid %d): %w", info.Pid, err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if !processAlive(info.Pid) {
+			return info, nil
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	return info, fmt.Errorf("server (pid %d) did not stop within %s", info.Pid, timeout)
+}
+
+// DiscoverTemporalService connects to the running persistent server, or returns ErrServerNotRunning
+func DiscoverTemporalService(stateDir string) (*RemoteService, error) {
+	return DiscoverTemporalServiceWithOptions(stateDir, ConnectOptions{})
+}
+
+// DiscoverTemporalServiceWithOptions connects to the running persistent server using options
+// for everything but the address and namespace, or returns ErrServerNotRunning
+func DiscoverTemporalServiceWithOptions(stateDir string, options ConnectOptions) (*RemoteService, error) {
+	info, err := ReadServerInfo(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	options.Address = info.Address
+	options.Namespace = info.Namespace
+	return Connect(options)
+}
+
+func readPid(pidFile string) (int, error) {
+	content, err := os.ReadFile(pidFile)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(content)))
+}
+
+// processAlive reports whether a process with the pid exists
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
@@ -0,0 +1,150 @@
+package temporal
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
+)
+
+// Service is a Temporal server the operations workflows run on, either a TemporalService
+// started by this process or an existing cluster reached with Connect
+type Service interface {
+	// GetClient returns the Temporal client connected to the server
+	GetClient() client.Client
+	// GetFrontendHostPort returns the frontend host:port
+	GetFrontendHostPort() string
+	// GetNamespace returns the namespace the client is connected to
+	GetNamespace() string
+	// Stop closes the client and stops the server if this process started it
+	Stop() error
+}
+
+var (
+	_ Service = (*TemporalService)(nil)
+	_ Service = (*RemoteService)(nil)
+)
+
+// ConnectOptions configures the connection to an existing Temporal cluster or Temporal Cloud namespace
+type ConnectOptions struct {
+	// Address is the frontend host:port (required)
+	Address string
+	// Namespace is the namespace to connect to (optional, defaults to 'default')
+	Namespace string
+	// APIKey authenticates with an API key and implies TLS (optional)
+	APIKey string
+	// TLS enables TLS without client certificates (optional)
+	TLS bool
+	// TLSServerName overrides the server name verified by TLS (optional)
+	TLSServerName string
+	// TLSServerCACertPath is a CA bundle for servers with private certificates (optional)
+	TLSServerCACertPath string
+	// TLSClientCertPath and TLSClientKeyPath authenticate with mTLS and imply TLS (optional)
+	TLSClientCertPath string
+	TLSClientKeyPath  string
+	// MetricsHandler receives the SDK client and worker metrics (optional)
+	MetricsHandler client.MetricsHandler
+	// Interceptors are client interceptors; those that are also worker interceptors apply to workers too (optional)
+	Interceptors []interceptor.ClientInterceptor
+}
+
+// tlsEnabled reports whether any of the options requires TLS
+func (o *ConnectOptions) tlsEnabled() bool {
+	return o.TLS || o.APIKey != "" || o.TLSServerName != "" || o.TLSServerCACertPath != "" || o.TLSClientCertPath != ""
+}
+
+// clientOptions converts the options into SDK client options
+func (o *ConnectOptions) clientOptions() (client.Options, error) {
+	options := client.Options{
+		HostPort:       o.Address,
+		Namespace:      o.Namespace,
+		MetricsHandler: o.MetricsHandler,
+		Interceptors:   o.Interceptors,
+	}
+	if options.Namespace == "" {
+		options.Namespace = DefaultNamespace
+	}
+	if o.APIKey != "" {
+		options.Credentials = client.NewAPIKeyStaticCredentials(o.APIKey)
+	}
+	if !o.tlsEnabled() {
+		return options, nil
+	}
+
+	tlsConfig := &tls.Config{ServerName: o.TLSServerName}
+	if o.TLSServerCACertPath != "" {
+		pem, err := os.ReadFile(o.TLSServerCACertPath)
+		if err != nil {
+			return options, fmt.Errorf("failed to read server CA cert: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return options, fmt.Errorf("no certificates found in %s", o.TLSServerCACertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if o.TLSClientCertPath != "" || o.TLSClientKeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(o.TLSClientCertPath, o.TLSClientKeyPath)
+		if err != nil {
+			return options, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	options.ConnectionOptions.TLS = tlsConfig
+	return options, nil
+}
+
+// RemoteService is a connection to a Temporal server this process did not start
+type RemoteService struct {
+	client    client.Client
+	address   string
+	namespace string
+}
+
+// Connect dials an existing Temporal server and verifies the connection
+func Connect(options ConnectOptions) (*RemoteService, error) {
+	if options.Address == "" {
+		return nil, fmt.Errorf("a Temporal address is required")
+	}
+	clientOptions, err := options.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	// Dial verifies the connection before returning
+	temporalClient, err := client.Dial(clientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Temporal server at %s: %w", options.Address, err)
+	}
+	return &RemoteService{
+		client:    temporalClient,
+		address:   options.Address,
+		namespace: clientOptions.Namespace,
+	}, nil
+}
+
+// GetClient returns the Temporal client
+func (r *RemoteService) GetClient() client.Client {
+	return r.client
+}
+
+// GetFrontendHostPort returns the frontend host:port string
+func (r *RemoteService) GetFrontendHostPort() string {
+	return r.address
+}
+
+// GetNamespace returns the namespace the client is connected to
+func (r *RemoteService) GetNamespace() string {
+	return r.namespace
+}
+
+// Stop closes the client connection; the server keeps running
+func (r *RemoteService) Stop() error {
+	if r.client != nil {
+		r.client.Close()
+	}
+	return nil
+}
@@ -15,23 +15,39 @@ type TemporalService struct {
 	devServer *testsuite.DevServer
 	client    client.Client
 	port      int
+	uiPort    int
+	namespace string
 }
 
-// NewTemporalService creates and starts a new Temporal dev server with client
+// NewTemporalService creates and starts a new headless Temporal dev server with client
 func NewTemporalService() (*TemporalService, error) {
+	return NewTemporalServiceWithOptions(TemporalServiceOptions{})
+}
+
+// NewTemporalServiceWithOptions creates and starts a new Temporal dev server configured by options
+func NewTemporalServiceWithOptions(options TemporalServiceOptions) (*TemporalService, error) {
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid TemporalService options: %w", err)
+	}
+
 	// Pick an available port
-	port, err := getAvailablePort()
-	if err != nil {
-		return nil, fmt.Errorf("failed to find available port: %w", err)
+	var err error
+	port := options.Port
+	if port == 0 {
+		if port, err = getAvailablePort(); err != nil {
+			return nil, fmt.Errorf("failed to find available port: %w", err)
+		}
+	}
+
+	// Pick the UI port too so it can be reported
+	if options.EnableUI && options.UIPort == 0 {
+		if options.UIPort, err = getAvailablePort(); err != nil {
+			return nil, fmt.Errorf("failed to find available UI port: %w", err)
+		}
 	}
 
 	// Create and start Temporal dev server
-	devServer, err := testsuite.StartDevServer(context.Background(), testsuite.DevServerOptions{
-		ClientOptions: &client.Options{
-			HostPort: fmt.Sprintf("localhost:%d", port),
-		},
-		EnableUI: false, // headless mode
-	})
+	devServer, err := testsuite.StartDevServer(context.Background(), options.devServerOptions(port))
 	if err != nil {
 		return nil, fmt.Errorf("failed to start Temporal dev server: %w", err)
 	}
@@ -53,6 +69,8 @@ func NewTemporalService() (*TemporalService, error) {
 		devServer: devServer,
 		client:    temporalClient,
 		port:      port,
+		uiPort:    options.UIPort,
+		namespace: options.namespace(),
 	}, nil
 }
 
@@ -94,6 +112,14 @@ func (t *TemporalService) GetPort() int {
 	return t.port
 }
 
+// GetNamespace returns the namespace the client is connected to
+func (t *TemporalService) GetNamespace() string {
+	if t.namespace == "" {
+		return DefaultNamespace
+	}
+	return t.namespace
+}
+
 // GetFrontendHostPort returns the frontend host:port string
 func (t *TemporalService) GetFrontendHostPort() string {
 	if t.devServer != nil {
@@ -101,3 +127,11 @@ func (t *TemporalService) GetFrontendHostPort() string {
 	}
 	return fmt.Sprintf("localhost:%d", t.port)
 }
+
+// GetUIHostPort returns the Web UI host:port string, or an empty string when the UI is disabled
+func (t *TemporalService) GetUIHostPort() string {
+	if t.uiPort == 0 {
+		return ""
+	}
+	return fmt.Sprintf("localhost:%d", t.uiPort)
+}
@@ -0,0 +1,79 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.temporal.io/sdk/client"
+)
+
+// HealthServer serves liveness on /healthz and readiness on /readyz for a worker.
+// Readiness requires the worker to be marked ready and the Temporal server to be reachable.
+type HealthServer struct {
+	temporalClient client.Client
+	server         *http.Server
+	ready          atomic.Bool
+}
+
+// NewHealthServer creates a HealthServer listening on address once started
+func NewHealthServer(address string, temporalClient client.Client) *HealthServer {
+	h := &HealthServer{temporalClient: temporalClient}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", h.handleHealth)
+	mux.HandleFunc("/readyz", h.handleReady)
+	h.server = &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return h
+}
+
+// Start listens in the background and returns the bound address
+func (h *HealthServer) Start() (string, error) {
+	listener, err := net.Listen("tcp", h.server.Addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to listen on %s: %w", h.server.Addr, err)
+	}
+	go func() {
+		if err := h.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("health server stopped: %v\n", err)
+		}
+	}()
+	return listener.Addr().String(), nil
+}
+
+// SetReady marks the worker ready to receive work, or not ready while it drains
+func (h *HealthServer) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// Stop shuts the health server down
+func (h *HealthServer) Stop(ctx context.Context) error {
+	return h.server.Shutdown(ctx)
+}
+
+func (h *HealthServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "ok")
+}
+
+func (h *HealthServer) handleReady(w http.ResponseWriter, r *http.Request) {
+	if !h.ready.Load() {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	if _, err := h.temporalClient.CheckHealth(ctx, &client.CheckHealthRequest{}); err != nil {
+		http.Error(w, fmt.Sprintf("temporal unavailable: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "ready")
+}
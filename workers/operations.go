@@ -3,89 +3,156 @@ package workers
 import (
 	"fmt"
 
-	"temporal-jumpstart-operations/commands/operations"
+	"temporal-jumpstart-operations/desiredstate"
+	"temporal-jumpstart-operations/notify"
+	"temporal-jumpstart-operations/policy"
+	"temporal-jumpstart-operations/secrets"
 	"temporal-jumpstart-operations/workflows"
 	"temporal-jumpstart-operations/workflows/activities"
 
 	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
 	"go.temporal.io/sdk/worker"
 )
 
+// DefaultTaskQueue is the task queue the operations workflows and activities run on
+const DefaultTaskQueue = "operations"
+
 // OperationsWorker manages the operations task queue worker
 type OperationsWorker struct {
 	// temporalClient is the Temporal SDK client
 	temporalClient client.Client
 	// cloudClient is the Temporal Cloud service client
 	cloudClient cloudservicev1.CloudServiceClient
+	// activities are the operations activities registered on the worker
+	activities *activities.Activities
 	// worker is the Temporal SDK worker instance
 	worker worker.Worker
-	// closer is used to close the cloud client connection
-	closer func() error
 }
 
-// NewOperationsWorker creates a new operations worker with the provided Temporal client
-func NewOperationsWorker(temporalClient client.Client) (*OperationsWorker, error) {
-	// Create cloud client for activities
-	cloudClient, closer, err := operations.NewCloudServiceClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create cloud client: %w", err)
+// OperationsWorkerOptions configures the operations worker
+type OperationsWorkerOptions struct {
+	// CloudClient is the Temporal Cloud service client the activities call (optional). Without one the worker
+	// only serves operations that never reach Cloud, like Nexus endpoints on the Temporal server it polls.
+	CloudClient cloudservicev1.CloudServiceClient
+	// TaskQueue is the task queue to poll (optional, defaults to 'operations')
+	TaskQueue string
+	// WorkerOptions are passed to the SDK worker, e.g. concurrency limits, identity and stop timeout (optional)
+	WorkerOptions worker.Options
+	// Interceptors are appended to WorkerOptions.Interceptors (optional). Client interceptors that are
+	// also worker interceptors, like the OpenTelemetry tracing interceptor, already apply and must not be repeated here.
+	Interceptors []interceptor.WorkerInterceptor
+	// Workflows are registered in addition to the operations workflows (optional)
+	Workflows []interface{}
+	// Activities are registered in addition to the operations activities, either functions or structs (optional)
+	Activities []interface{}
+	// DryRun makes the operations activities describe their local side effects instead of performing them (optional).
+	// CloudClient must then skip mutations too, and the worker should poll a task queue only dry runs are started on.
+	DryRun bool
+	// Notifier is told when long-running operations finish or fail (optional)
+	Notifier notify.Notifier
+	// NotifyTemplates render the notifications (optional, defaults to the built-in templates)
+	NotifyTemplates *notify.Templates
+	// SecretSinks restricts the output paths workflow input may write API keys and client config to
+	// (optional, nil allows every output path including exec:// commands)
+	SecretSinks *secrets.SinkPolicy
+	// Policy is the organisation policy every created or updated service account and API key must satisfy.
+	// Without one those operations fail, unless NoPolicy is set.
+	Policy *policy.Policy
+	// NoPolicy lets the workflows create and update identities when there is no Policy (optional)
+	NoPolicy bool
+}
+
+// NewOperationsWorker creates a new operations worker with the provided Temporal client.
+// The worker does not own the Cloud client; the caller closes it after stopping the worker.
+func NewOperationsWorker(temporalClient client.Client, options OperationsWorkerOptions) (*OperationsWorker, error) {
+	if temporalClient == nil {
+		return nil, fmt.Errorf("a Temporal client is required")
+	}
+	if options.TaskQueue == "" {
+		options.TaskQueue = DefaultTaskQueue
 	}
 
-	// Create worker on the "operations" task queue
-	w := worker.New(temporalClient, "operations", worker.Options{})
+	workerOptions := options.WorkerOptions
+	workerOptions.Interceptors = append(append([]interceptor.WorkerInterceptor(nil), workerOptions.Interceptors...), options.Interceptors...)
 
-	// Register the CreateOperationsServiceAccount workflow
-	w.RegisterWorkflow(workflows.CreateOperationsServiceAccount)
+	// Create worker on the operations task queue
+	w := worker.New(temporalClient, options.TaskQueue, workerOptions)
 
-	// Create activities instance using the factory method
-	activitiesInstance := activities.NewActivities(cloudClient)
+	// Register the operations workflows
+	w.RegisterWorkflow(workflows.CreateOperationsServiceAccount)
+	w.RegisterWorkflow(workflows.ReconcileDesiredState)
+	w.RegisterWorkflow(workflows.DetectIdentityDrift)
+	w.RegisterWorkflow(workflows.CreateNamespace)
+	w.RegisterWorkflow(workflows.ImportUsers)
+	w.RegisterWorkflow(workflows.CreateNexusEndpoint)
+	w.RegisterWorkflow(workflows.ExportCloudAuditLogs)
+	w.RegisterWorkflow(workflows.ProvisionServiceAccounts)
+	w.RegisterWorkflow(workflows.DeleteOperationsServiceAccount)
+
+	// Create activities instance using the factory method; the operator client lets
+	// Nexus endpoints target the Temporal server the worker polls
+	activitiesInstance := activities.NewActivities(options.CloudClient)
+	activitiesInstance.OperatorClient = temporalClient.OperatorService()
+	activitiesInstance.DryRun = options.DryRun
+	activitiesInstance.Notifier = options.Notifier
+	activitiesInstance.NotifyTemplates = options.NotifyTemplates
+	activitiesInstance.SecretSinks = options.SecretSinks
 
 	// Register activities
 	w.RegisterActivity(activitiesInstance)
+	w.RegisterActivity(desiredstate.NewActivities(options.CloudClient))
+	w.RegisterActivity(&policy.Activities{
+		Policy:      options.Policy,
+		NoPolicy:    options.NoPolicy,
+		CloudClient: options.CloudClient,
+	})
+
+	// Register the embedding binary's own workflows and activities
+	for _, wf := range options.Workflows {
+		w.RegisterWorkflow(wf)
+	}
+	for _, a := range options.Activities {
+		w.RegisterActivity(a)
+	}
 
 	return &OperationsWorker{
 		temporalClient: temporalClient,
-		cloudClient:    cloudClient,
+		cloudClient:    options.CloudClient,
+		activities:     activitiesInstance,
 		worker:         w,
-		closer: func() error {
-			if closerInterface, ok := closer.(interface{ Close() error }); ok {
-				return closerInterface.Close()
-			}
-			return nil
-		},
 	}, nil
 }
 
+// Activities returns the operations activities registered on the worker, bound to its clients
+func (ow *OperationsWorker) Activities() *activities.Activities {
+	return ow.activities
+}
+
 // Start starts the operations worker
 func (ow *OperationsWorker) Start() error {
 	if ow.worker == nil {
 		return fmt.Errorf("worker not initialized")
 	}
 
-	// Start the worker (this is blocking)
-	return ow.worker.Run(worker.InterruptCh())
+	// Start the worker (this is non-blocking)
+	return ow.worker.Start()
 }
 
-// Stop stops the operations worker and cleans up resources
-func (ow *OperationsWorker) Stop() error {
-	var errors []error
+// Run runs the operations worker until interruptCh is closed or receives, then waits up to
+// WorkerOptions.WorkerStopTimeout for running activities to drain
+func (ow *OperationsWorker) Run(interruptCh <-chan interface{}) error {
+	if ow.worker == nil {
+		return fmt.Errorf("worker not initialized")
+	}
+	return ow.worker.Run(interruptCh)
+}
 
-	// Stop the worker
+// Stop stops the operations worker
+func (ow *OperationsWorker) Stop() error {
 	if ow.worker != nil {
 		ow.worker.Stop()
 	}
-
-	// Close cloud client connection
-	if ow.closer != nil {
-		if err := ow.closer(); err != nil {
-			errors = append(errors, fmt.Errorf("failed to close cloud client: %w", err))
-		}
-	}
-
-	if len(errors) > 0 {
-		return fmt.Errorf("errors during worker shutdown: %v", errors)
-	}
-
 	return nil
 }
@@ -0,0 +1,136 @@
+package activities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+)
+
+// Access is the serializable form of identityv1.Access passed between workflows and activities.
+// Roles and permissions use their short lowercase names, e.g. "developer" or "write".
+type Access struct {
+	// AccountRole is one of owner, admin, developer, finance_admin, read (optional, defaults to read)
+	AccountRole string `json:"accountRole,omitempty"`
+	// NamespacePermissions maps a namespace id to one of admin, write, read
+	NamespacePermissions map[string]string `json:"namespacePermissions,omitempty"`
+}
+
+// ParseAccountRole converts a short role name into an AccountAccess_Role
+func ParseAccountRole(role string) (identityv1.AccountAccess_Role, error) {
+	if role == "" {
+		return identityv1.AccountAccess_ROLE_READ, nil
+	}
+	v, ok := identityv1.AccountAccess_Role_value["ROLE_"+strings.ToUpper(role)]
+	if !ok || v == int32(identityv1.AccountAccess_ROLE_UNSPECIFIED) {
+		return identityv1.AccountAccess_ROLE_UNSPECIFIED, fmt.Errorf("unknown account role %q", role)
+	}
+	return identityv1.AccountAccess_Role(v), nil
+}
+
+// ParseNamespacePermission converts a short permission name into a NamespaceAccess_Permission
+func ParseNamespacePermission(permission string) (identityv1.NamespaceAccess_Permission, error) {
+	v, ok := identityv1.NamespaceAccess_Permission_value["PERMISSION_"+strings.ToUpper(permission)]
+	if !ok || v == int32(identityv1.NamespaceAccess_PERMISSION_UNSPECIFIED) {
+		return identityv1.NamespaceAccess_PERMISSION_UNSPECIFIED, fmt.Errorf("unknown namespace permission %q", permission)
+	}
+	return identityv1.NamespaceAccess_Permission(v), nil
+}
+
+// AccountRoleName returns the short name of an AccountAccess_Role, e.g. "developer"
+func AccountRoleName(role identityv1.AccountAccess_Role) string {
+	return strings.ToLower(strings.TrimPrefix(role.String(), "ROLE_"))
+}
+
+// NamespacePermissionName returns the short name of a NamespaceAccess_Permission, e.g. "write"
+func NamespacePermissionName(permission identityv1.NamespaceAccess_Permission) string {
+	return strings.ToLower(strings.TrimPrefix(permission.String(), "PERMISSION_"))
+}
+
+// Namespaces returns the namespaces the Access grants permissions on, sorted
+func (a *Access) Namespaces() []string {
+	if a == nil {
+		return nil
+	}
+	namespaces := make([]string, 0, len(a.NamespacePermissions))
+	for ns := range a.NamespacePermissions {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// ParseNamespacePermissions parses "{namespace}={permission}" entries into a namespace permission map
+func ParseNamespacePermissions(entries []string) (map[string]string, error) {
+	permissions := map[string]string{}
+	for _, entry := range entries {
+		ns, permission, ok := strings.Cut(entry, "=")
+		if !ok || ns == "" || permission == "" {
+			return nil, fmt.Errorf("invalid namespace permission %q, expected {namespace}={admin|write|read}", entry)
+		}
+		if _, err := ParseNamespacePermission(permission); err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", ns, err)
+		}
+		permissions[ns] = strings.ToLower(permission)
+	}
+	return permissions, nil
+}
+
+// ToIdentityAccess converts the Access into the Cloud API representation.
+// A nil Access yields read-only account access with no namespace permissions.
+func (a *Access) ToIdentityAccess() (*identityv1.Access, error) {
+	if a == nil {
+		a = &Access{}
+	}
+	role, err := ParseAccountRole(a.AccountRole)
+	if err != nil {
+		return nil, err
+	}
+	access := &identityv1.Access{
+		AccountAccess:     &identityv1.AccountAccess{Role: role},
+		NamespaceAccesses: map[string]*identityv1.NamespaceAccess{},
+	}
+	for ns, permission := range a.NamespacePermissions {
+		p, err := ParseNamespacePermission(permission)
+		if err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", ns, err)
+		}
+		access.NamespaceAccesses[ns] = &identityv1.NamespaceAccess{Permission: p}
+	}
+	return access, nil
+}
+
+// AccessFromIdentity converts the Cloud API representation back into an Access
+func AccessFromIdentity(access *identityv1.Access) *Access {
+	result := &Access{
+		AccountRole:          AccountRoleName(access.GetAccountAccess().GetRole()),
+		NamespacePermissions: map[string]string{},
+	}
+	for ns, nsAccess := range access.GetNamespaceAccesses() {
+		result.NamespacePermissions[ns] = NamespacePermissionName(nsAccess.GetPermission())
+	}
+	return result
+}
+
+// Equal reports whether both Access values grant the same role and namespace permissions
+func (a *Access) Equal(other *Access) bool {
+	return a.String() == other.String()
+}
+
+// String renders the Access in a stable, human readable form
+func (a *Access) String() string {
+	if a == nil {
+		a = &Access{}
+	}
+	role := a.AccountRole
+	if role == "" {
+		role = "read"
+	}
+	namespaces := make([]string, 0, len(a.NamespacePermissions))
+	for ns, permission := range a.NamespacePermissions {
+		namespaces = append(namespaces, ns+"="+strings.ToLower(permission))
+	}
+	sort.Strings(namespaces)
+	return fmt.Sprintf("account=%s namespaces=[%s]", strings.ToLower(role), strings.Join(namespaces, ","))
+}
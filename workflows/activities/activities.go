@@ -8,10 +8,21 @@ import (
 
 	"time"
 
+	"temporal-jumpstart-operations/clientconfig"
+	"temporal-jumpstart-operations/dryrun"
+	"temporal-jumpstart-operations/notify"
+	"temporal-jumpstart-operations/ownership"
+	"temporal-jumpstart-operations/secrets"
+
+	"go.temporal.io/api/operatorservice/v1"
 	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
 	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
 	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/temporal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -22,12 +33,42 @@ const ERR_OPERATION_NOT_READY = "operation not ready"
 const ERR_OPERATION_WILL_NOT_SUCCEED = "operation will not succeed"
 
 type CreateServiceAccountRequest struct {
+	Name             string  `json:"name"`
+	Description      string  `json:"description"`
+	Access           *Access `json:"access,omitempty"`
+	AsyncOperationId string  `json:"asyncOperationId"`
+}
+type CreateServiceAccountResponse struct {
+	ServiceAccountId string `json:"serviceAccountId"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+type UpdateServiceAccountRequest struct {
+	ServiceAccountId string  `json:"serviceAccountId"`
+	Description      string  `json:"description"`
+	Access           *Access `json:"access,omitempty"`
+	AsyncOperationId string  `json:"asyncOperationId"`
+}
+type UpdateServiceAccountResponse struct {
+	ServiceAccountId string `json:"serviceAccountId"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+type GetServiceAccountRequest struct {
+	Name string `json:"name"`
+}
+type GetServiceAccountResponse struct {
+	ServiceAccountId string `json:"serviceAccountId"`
+	// Owner is the owner tag of the service account description, if it has one
+	Owner *ownership.Owner `json:"owner,omitempty"`
+}
+type DeleteServiceAccountRequest struct {
 	Name             string `json:"name"`
-	Description      string `json:"description"`
 	AsyncOperationId string `json:"asyncOperationId"`
 }
-type CreateServiceAccountResponse struct {
+type DeleteServiceAccountResponse struct {
 	ServiceAccountId string `json:"serviceAccountId"`
+	AsyncOperationId string `json:"asyncOperationId"`
+	// Owner is the owner tag of the service account description, if it has one
+	Owner *ownership.Owner `json:"owner,omitempty"`
 }
 type CreateAPIKeyRequest struct {
 	ServiceAccountId string `json:"serviceAccountId"`
@@ -35,15 +76,53 @@ type CreateAPIKeyRequest struct {
 	Description      string `json:"description"`
 	AsyncOperationId string `json:"asyncOperationId"`
 	Duration         string `json:"duration"`
+	// OutputPath is the secret sink URI the API key token is written to; the token never leaves the activity
+	OutputPath string `json:"outputPath"`
+	// ConfigPath is the local directory Kubernetes manifests are written to (optional, defaults to the OutputPath directory)
+	ConfigPath string `json:"configPath,omitempty"`
+	// Recipients are age or OpenPGP public keys the key file is encrypted to (optional)
+	Recipients []string `json:"recipients,omitempty"`
+	// Output selects the extra formats the token is written in, e.g. a Kubernetes Secret (optional)
+	Output *clientconfig.OutputOptions `json:"output,omitempty"`
 }
 type CreateAPIKeyResponse struct {
 	ServiceAccountId string `json:"serviceAccountId"`
 	ApiKeyId         string `json:"apiKeyId"`
+	AsyncOperationId string `json:"asyncOperationId"`
+	// SecretLocation is where the token was written
+	SecretLocation string `json:"secretLocation,omitempty"`
 }
 type WriteApiKeyRequest struct {
-	ApiKeyId         string `json:"apiKeyId"`
-	ServiceAccountId string `json:"serviceAccountId"`
-	OutputPath       string `json:"outputPath"`
+	ApiKeyId         string   `json:"apiKeyId"`
+	ApiKeyName       string   `json:"apiKeyName"`
+	ServiceAccountId string   `json:"serviceAccountId"`
+	OutputPath       string   `json:"outputPath"`
+	ConfigPath       string   `json:"configPath,omitempty"`
+	Recipients       []string `json:"recipients,omitempty"`
+	// Output selects the extra formats the token is written in (optional)
+	Output *clientconfig.OutputOptions `json:"output,omitempty"`
+	// Token is the API key secret. It is never serialized so it stays out of workflow history.
+	Token string `json:"-"`
+}
+type WriteApiKeyResponse struct {
+	Location string `json:"location"`
+}
+type ResolveSecretOutputRequest struct {
+	OutputPath string   `json:"outputPath"`
+	ConfigPath string   `json:"configPath,omitempty"`
+	Recipients []string `json:"recipients,omitempty"`
+	ApiKeyName string   `json:"apiKeyName"`
+	// Output selects the extra formats the token is written in (optional)
+	Output *clientconfig.OutputOptions `json:"output,omitempty"`
+}
+type ResolveSecretOutputResponse struct {
+	// ConfigPath is the directory config files are written to, empty when the sink is not local
+	ConfigPath string `json:"configPath,omitempty"`
+	// ApiKeyFile is the plaintext key file the client config can reference, if one is written
+	ApiKeyFile string `json:"apiKeyFile,omitempty"`
+}
+type ValidateNamespacesRequest struct {
+	Namespaces []string `json:"namespaces"`
 }
 type CheckOperationCompletionRequest struct {
 	AsyncOperationId string `json:"asyncOperationId"`
@@ -57,6 +136,18 @@ type CheckOperationCompletionResponse struct {
 type Activities struct {
 	// CloudClient is the Temporal Cloud service client for making API calls
 	CloudClient cloudservicev1.CloudServiceClient
+	// OperatorClient is the operator service of the Temporal server the worker is connected to (optional).
+	// It is only needed to manage Nexus endpoints on that server rather than in Temporal Cloud.
+	OperatorClient operatorservice.OperatorServiceClient
+	// DryRun describes local side effects, like writing API keys, client config and local Nexus endpoints, instead
+	// of performing them. Cloud mutations are skipped by a CloudClient built with dryrun.UnaryClientInterceptor.
+	DryRun bool
+	// Notifier tells teams how long-running operations ended (optional, notifications are skipped when nil)
+	Notifier notify.Notifier
+	// NotifyTemplates render the notifications (optional, defaults to the built-in templates)
+	NotifyTemplates *notify.Templates
+	// SecretSinks restricts where API keys and client config are written (optional, nil allows every output path)
+	SecretSinks *secrets.SinkPolicy
 }
 
 // NewActivities creates a new Activities instance with the provided cloud client
@@ -67,27 +158,36 @@ func NewActivities(cloudClient cloudservicev1.CloudServiceClient) *Activities {
 }
 
 func (a *Activities) CreateServiceAccount(ctx context.Context, args *CreateServiceAccountRequest) (*CreateServiceAccountResponse, error) {
-	sas, err := a.CloudClient.GetServiceAccounts(ctx, &cloudservicev1.GetServiceAccountsRequest{
-		PageSize: 10000,
-	})
+	sas, err := ListServiceAccounts(ctx, a.CloudClient)
 	if err != nil {
 		return nil, err
 	}
-	if sas != nil && len(sas.ServiceAccount) > 0 {
-		return nil, temporal.NewNonRetryableApplicationError(ERR_ALREADY_EXISTS, "already exists", nil)
-	}
-	if slices.IndexFunc(sas.ServiceAccount, func(sa *identityv1.ServiceAccount) bool {
+	operationId := asyncOperationId(ctx, args.AsyncOperationId)
+	if i := slices.IndexFunc(sas, func(sa *identityv1.ServiceAccount) bool {
 		return strings.EqualFold(sa.Spec.Name, args.Name)
-	}) != -1 {
-		return nil, temporal.NewNonRetryableApplicationError(ERR_ALREADY_EXISTS, "already exists", nil)
+	}); i != -1 {
+		// a retry finds the account its earlier attempt created and adopts it rather than failing
+		if operationId == "" || sas[i].GetAsyncOperationId() != operationId {
+			return nil, temporal.NewNonRetryableApplicationError(ERR_ALREADY_EXISTS, "already exists", nil)
+		}
+		return &CreateServiceAccountResponse{
+			ServiceAccountId: sas[i].Id,
+			AsyncOperationId: operationId,
+		}, nil
+	}
+
+	access, err := args.Access.ToIdentityAccess()
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
 	}
 
 	sa, err := a.CloudClient.CreateServiceAccount(ctx, &cloudservicev1.CreateServiceAccountRequest{
 		Spec: &identityv1.ServiceAccountSpec{
 			Name:        args.Name,
 			Description: args.Description,
+			Access:      access,
 		},
-		AsyncOperationId: args.AsyncOperationId,
+		AsyncOperationId: operationId,
 	})
 	if err != nil {
 		return nil, err
@@ -95,28 +195,122 @@ func (a *Activities) CreateServiceAccount(ctx context.Context, args *CreateServi
 
 	return &CreateServiceAccountResponse{
 		ServiceAccountId: sa.ServiceAccountId,
+		AsyncOperationId: sa.GetAsyncOperation().GetId(),
 	}, nil
 }
+
+// UpdateServiceAccount replaces the description and access of an existing service account,
+// keeping its name as-is
+func (a *Activities) UpdateServiceAccount(ctx context.Context, args *UpdateServiceAccountRequest) (*UpdateServiceAccountResponse, error) {
+	current, err := a.CloudClient.GetServiceAccount(ctx, &cloudservicev1.GetServiceAccountRequest{
+		ServiceAccountId: args.ServiceAccountId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := args.Access.ToIdentityAccess()
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+
+	resp, err := a.CloudClient.UpdateServiceAccount(ctx, &cloudservicev1.UpdateServiceAccountRequest{
+		ServiceAccountId: args.ServiceAccountId,
+		Spec: &identityv1.ServiceAccountSpec{
+			Name:        current.ServiceAccount.Spec.Name,
+			Description: args.Description,
+			Access:      access,
+		},
+		ResourceVersion:  current.ServiceAccount.ResourceVersion,
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateServiceAccountResponse{
+		ServiceAccountId: args.ServiceAccountId,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+// DeleteServiceAccount deletes the service account with the name, or fails with a non-retryable ERR_NOT_FOUND error
+// GetServiceAccount looks a service account up by name and fails with a non-retryable error when there is none
+func (a *Activities) GetServiceAccount(ctx context.Context, args *GetServiceAccountRequest) (*GetServiceAccountResponse, error) {
+	sas, err := ListServiceAccounts(ctx, a.CloudClient)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(sas, func(sa *identityv1.ServiceAccount) bool {
+		return strings.EqualFold(sa.Spec.Name, args.Name)
+	})
+	if i == -1 {
+		return nil, temporal.NewNonRetryableApplicationError(ERR_NOT_FOUND, "not found", fmt.Errorf("service account %s", args.Name))
+	}
+	_, owner := ownership.ParseDescription(sas[i].GetSpec().GetDescription())
+	return &GetServiceAccountResponse{ServiceAccountId: sas[i].Id, Owner: owner}, nil
+}
+
+func (a *Activities) DeleteServiceAccount(ctx context.Context, args *DeleteServiceAccountRequest) (*DeleteServiceAccountResponse, error) {
+	sas, err := ListServiceAccounts(ctx, a.CloudClient)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(sas, func(sa *identityv1.ServiceAccount) bool {
+		return strings.EqualFold(sa.Spec.Name, args.Name)
+	})
+	if i == -1 {
+		return nil, temporal.NewNonRetryableApplicationError(ERR_NOT_FOUND, "not found", fmt.Errorf("service account %s", args.Name))
+	}
+
+	resp, err := a.CloudClient.DeleteServiceAccount(ctx, &cloudservicev1.DeleteServiceAccountRequest{
+		ServiceAccountId: sas[i].Id,
+		ResourceVersion:  sas[i].ResourceVersion,
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, owner := ownership.ParseDescription(sas[i].GetSpec().GetDescription())
+	return &DeleteServiceAccountResponse{
+		ServiceAccountId: sas[i].Id,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+		Owner:            owner,
+	}, nil
+}
+
 func (a *Activities) CreateAPIKey(ctx context.Context, args *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
 	// we could filter this list by OwnerId to support duplicate ApiKey names (disambiguated by the ownerId)
 	// but instead this is enforcing the global uniqueness of the ApiKey name
-	keys, err := a.CloudClient.GetApiKeys(ctx, &cloudservicev1.GetApiKeysRequest{
-		PageSize:  10000,
-		OwnerType: identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT,
-	})
+	keys, err := ListApiKeys(ctx, a.CloudClient, identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT)
 	if err != nil {
 		return nil, err
 	}
-	if slices.IndexFunc(keys.ApiKeys, func(key *identityv1.ApiKey) bool {
+	if i := slices.IndexFunc(keys, func(key *identityv1.ApiKey) bool {
 		return strings.EqualFold(key.Spec.DisplayName, args.Name)
-	}) != -1 {
-		return nil, temporal.NewNonRetryableApplicationError(ERR_ALREADY_EXISTS, "already exists", nil)
+	}); i != -1 {
+		if !createdByEarlierAttempt(ctx, keys[i], args.ServiceAccountId) {
+			return nil, temporal.NewNonRetryableApplicationError(ERR_ALREADY_EXISTS, "already exists", nil)
+		}
+		// an earlier attempt created the key but its token was lost with that attempt, so the key is replaced
+		if keys[i].GetState() != resourcev1.ResourceState_RESOURCE_STATE_DELETED {
+			if err := a.deleteApiKey(ctx, keys[i].Id); err != nil {
+				return nil, fmt.Errorf("failed to delete api key %s left by an earlier attempt: %w", keys[i].Id, err)
+			}
+			return nil, fmt.Errorf("api key %s left by an earlier attempt is being deleted", keys[i].Id)
+		}
 	}
 
 	xp, err := ParseDuration(args.Duration)
 	if err != nil {
 		return nil, err
 	}
+	// the key is only created when its token can be written
+	if args.OutputPath != "" {
+		if _, err := a.secretSink(args.OutputPath, args.ConfigPath, args.Recipients, args.Output); err != nil {
+			return nil, err
+		}
+	}
 	ak, err := a.CloudClient.CreateApiKey(ctx, &cloudservicev1.CreateApiKeyRequest{
 		Spec: &identityv1.ApiKeySpec{
 			OwnerId:     args.ServiceAccountId,
@@ -130,13 +324,195 @@ func (a *Activities) CreateAPIKey(ctx context.Context, args *CreateAPIKeyRequest
 		return nil, err
 	}
 
-	return &CreateAPIKeyResponse{
+	response := &CreateAPIKeyResponse{
 		ApiKeyId:         ak.KeyId,
 		ServiceAccountId: args.ServiceAccountId,
-	}, nil
+		AsyncOperationId: ak.GetAsyncOperation().GetId(),
+	}
+
+	// the token is only ever returned by this call so it has to be persisted right here
+	if args.OutputPath != "" && a.DryRun {
+		dryrun.Printf("write api key %s to %s", args.Name, args.OutputPath)
+		response.SecretLocation = args.OutputPath
+	} else if args.OutputPath != "" {
+		written, err := a.WriteApiKey(ctx, &WriteApiKeyRequest{
+			ApiKeyId:         ak.KeyId,
+			ApiKeyName:       args.Name,
+			ServiceAccountId: args.ServiceAccountId,
+			OutputPath:       args.OutputPath,
+			ConfigPath:       args.ConfigPath,
+			Recipients:       args.Recipients,
+			Output:           args.Output,
+			Token:            ak.Token,
+		})
+		if err != nil {
+			// nobody holds the token so the key is deleted rather than left behind unusable
+			if derr := a.deleteApiKey(ctx, ak.KeyId); derr != nil {
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("failed to write api key %s: %v; the key could not be deleted and has to be deleted by hand: %v", ak.KeyId, err, derr),
+					"failed to write api key", err)
+			}
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("failed to write api key %s, the key was deleted: %v", ak.KeyId, err), "failed to write api key", err)
+		}
+		response.SecretLocation = written.Location
+	}
+
+	return response, nil
+}
+
+// asyncOperationId returns the requested async operation id or, when there is none, one derived from the workflow and
+// activity ids so that every attempt of the activity submits the same operation
+func asyncOperationId(ctx context.Context, requested string) string {
+	if requested != "" || !activity.IsActivity(ctx) {
+		return requested
+	}
+	info := activity.GetInfo(ctx)
+	return fmt.Sprintf("%s-%s", info.WorkflowExecution.ID, info.ActivityID)
+}
+
+// createdByEarlierAttempt reports whether a retry of this activity finds the key one of its earlier attempts created
+func createdByEarlierAttempt(ctx context.Context, key *identityv1.ApiKey, serviceAccountId string) bool {
+	if !activity.IsActivity(ctx) {
+		return false
+	}
+	info := activity.GetInfo(ctx)
+	return info.Attempt > 1 &&
+		key.GetSpec().GetOwnerId() == serviceAccountId &&
+		!key.GetCreatedTime().AsTime().Before(info.ScheduledTime)
+}
+
+// deleteApiKey deletes the api key at its current resource version; a key that is already being deleted is left alone
+func (a *Activities) deleteApiKey(ctx context.Context, keyId string) error {
+	current, err := a.CloudClient.GetApiKey(ctx, &cloudservicev1.GetApiKeyRequest{KeyId: keyId})
+	if err != nil {
+		return err
+	}
+	switch current.GetApiKey().GetState() {
+	case resourcev1.ResourceState_RESOURCE_STATE_DELETING, resourcev1.ResourceState_RESOURCE_STATE_DELETED:
+		return nil
+	}
+	_, err = a.CloudClient.DeleteApiKey(ctx, &cloudservicev1.DeleteApiKeyRequest{
+		KeyId:           keyId,
+		ResourceVersion: current.GetApiKey().GetResourceVersion(),
+	})
+	return err
+}
+
+// WriteApiKey writes the API key token to the secret sink selected by the OutputPath scheme, encrypted to the Recipients when set,
+// and to a Kubernetes Secret or SealedSecret in {ConfigPath}/kubernetes when those formats are selected
+func (a *Activities) WriteApiKey(ctx context.Context, args *WriteApiKeyRequest) (*WriteApiKeyResponse, error) {
+	if args.Token == "" {
+		return nil, fmt.Errorf("api key %s has no token to write", args.ApiKeyId)
+	}
+	sink, err := a.secretSink(args.OutputPath, args.ConfigPath, args.Recipients, args.Output)
+	if err != nil {
+		return nil, err
+	}
+	location, err := sink.Write(ctx, &secrets.Secret{
+		Name:             args.ApiKeyName,
+		ApiKeyId:         args.ApiKeyId,
+		ServiceAccountId: args.ServiceAccountId,
+		Value:            args.Token,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := secrets.CheckName(args.ApiKeyName); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	configPath := args.ConfigPath
+	if configPath == "" {
+		configPath = secrets.LocalDir(args.OutputPath)
+	}
+	if configPath != "" {
+		if _, err := args.Output.WriteApiKeyManifests(configPath, args.Token); err != nil {
+			return nil, fmt.Errorf("failed to write api key manifests: %w", err)
+		}
+	}
+	return &WriteApiKeyResponse{Location: location}, nil
+}
+
+// ValidateSecretOutput rejects a plaintext Kubernetes Secret manifest for a token the sink encrypts,
+// which would leave the token readable next to its encrypted copy
+func ValidateSecretOutput(sink secrets.SecretSink, output *clientconfig.OutputOptions) error {
+	if secrets.Encrypts(sink) && output.Has(clientconfig.FormatSecret) {
+		return fmt.Errorf("the %s format writes the api key in plaintext, use %s or %s with an encrypted output path or recipients",
+			clientconfig.FormatSecret, clientconfig.FormatSealedSecret, clientconfig.FormatExternalSecret)
+	}
+	return nil
 }
 
-func (a *Activities) WriteApiKey(ctx context.Context, args *WriteApiKeyRequest) error {
+// secretSink returns the sink for the output path, failing with a non-retryable ValidationError
+// when the sink, or the directory config files are written to, is not allowed by the SecretSinks policy
+// or the output formats would undo its encryption
+func (a *Activities) secretSink(outputPath string, configPath string, recipients []string, output *clientconfig.OutputOptions) (secrets.SecretSink, error) {
+	sink, err := secrets.ParseSink(outputPath)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if err := a.SecretSinks.CheckSink(sink); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if configPath != "" {
+		if err := a.SecretSinks.CheckDir(configPath); err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+		}
+	}
+	if sink, err = secrets.WithRecipients(sink, recipients); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if err := ValidateSecretOutput(sink, output); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	return sink, nil
+}
+
+// ResolveSecretOutput checks the output path of an API key before anything is created and resolves where the
+// config files go. Sinks read recipient files and environment variables, so workflows resolve them here.
+func (a *Activities) ResolveSecretOutput(ctx context.Context, args *ResolveSecretOutputRequest) (*ResolveSecretOutputResponse, error) {
+	if err := secrets.CheckName(args.ApiKeyName); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	configPath := args.ConfigPath
+	if configPath == "" {
+		configPath = secrets.LocalDir(args.OutputPath)
+	}
+	if _, err := a.secretSink(args.OutputPath, configPath, args.Recipients, args.Output); err != nil {
+		return nil, err
+	}
+	if configPath == "" && args.Output.HasKubernetes() {
+		return nil, temporal.NewNonRetryableApplicationError(
+			"configPath is required for the Kubernetes formats when outputPath is not a local path", "ValidationError", nil)
+	}
+	response := &ResolveSecretOutputResponse{ConfigPath: configPath}
+	// Encrypted key files cannot be read by the SDK, so the profiles only reference plaintext ones
+	if len(args.Recipients) == 0 {
+		response.ApiKeyFile = secrets.KeyFile(args.OutputPath, args.ApiKeyName)
+	}
+	return response, nil
+}
+
+// ValidateNamespaces fails with a non-retryable ValidationError if any of the namespaces does not exist
+func (a *Activities) ValidateNamespaces(ctx context.Context, args *ValidateNamespacesRequest) error {
+	var missing []string
+	for _, ns := range args.Namespaces {
+		_, err := a.CloudClient.GetNamespace(ctx, &cloudservicev1.GetNamespaceRequest{
+			Namespace: ns,
+		})
+		if status.Code(err) == codes.NotFound {
+			missing = append(missing, ns)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(missing) > 0 {
+		return temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("namespaces not found: %s", strings.Join(missing, ", ")), "ValidationError", nil)
+	}
 	return nil
 }
 
@@ -0,0 +1,120 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"temporal-jumpstart-operations/audit"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// auditLogSettleDelay keeps the export behind the present so records emitted late are not skipped
+const auditLogSettleDelay = time.Minute
+
+// AuditLogCursor is the position of an audit log export: every record emitted before After has been exported,
+// as have the records emitted exactly at After listed in SeenLogIds
+type AuditLogCursor struct {
+	After      time.Time `json:"after"`
+	SeenLogIds []string  `json:"seenLogIds,omitempty"`
+}
+
+// advance moves the cursor past a record
+func (c *AuditLogCursor) advance(record *auditlogv1.LogRecord) {
+	emitted := record.GetEmitTime().AsTime()
+	if emitted.After(c.After) {
+		c.After = emitted
+		c.SeenLogIds = nil
+	}
+	c.SeenLogIds = append(c.SeenLogIds, record.GetLogId())
+}
+
+// exported reports whether the cursor is already past a record
+func (c *AuditLogCursor) exported(record *auditlogv1.LogRecord) bool {
+	emitted := record.GetEmitTime().AsTime()
+	return emitted.Before(c.After) || emitted.Equal(c.After) && slices.Contains(c.SeenLogIds, record.GetLogId())
+}
+
+type ExportAuditLogsRequest struct {
+	// Sink is the export sink URI, see audit.ParseExportSink (required)
+	Sink string `json:"sink"`
+	// Cursor is where the export resumes
+	Cursor AuditLogCursor `json:"cursor"`
+	// PageSize is the number of records requested per page (optional, defaults to 100)
+	PageSize int32 `json:"pageSize"`
+	// MaxPages bounds the pages exported by one activity so progress is checkpointed in the workflow (optional, defaults to 50)
+	MaxPages int `json:"maxPages"`
+}
+type ExportAuditLogsResponse struct {
+	Cursor   AuditLogCursor `json:"cursor"`
+	Exported int            `json:"exported"`
+	// CaughtUp is true when every record up to the settle delay has been exported
+	CaughtUp bool `json:"caughtUp"`
+}
+
+// ExportAuditLogs pages through the account audit log from the cursor and ships each page to the sink.
+// Progress is heartbeated so a retried attempt resumes after the last exported page.
+func (a *Activities) ExportAuditLogs(ctx context.Context, args *ExportAuditLogsRequest) (*ExportAuditLogsResponse, error) {
+	sink, err := audit.ParseExportSink(args.Sink)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if args.PageSize <= 0 {
+		args.PageSize = 100
+	}
+	if args.MaxPages <= 0 {
+		args.MaxPages = 50
+	}
+
+	response := &ExportAuditLogsResponse{Cursor: args.Cursor}
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, response); err != nil {
+			return nil, fmt.Errorf("failed to restore audit log export progress: %w", err)
+		}
+	}
+
+	// a page token continues the query it was returned for, so the window stays fixed while following tokens
+	// and the cursor only filters out the records exported before
+	start, end := response.Cursor.After, time.Now().Add(-auditLogSettleDelay)
+	pageToken := ""
+	for page := 0; page < args.MaxPages; page++ {
+		resp, err := a.CloudClient.GetAuditLogs(ctx, &cloudservicev1.GetAuditLogsRequest{
+			PageSize:           args.PageSize,
+			PageToken:          pageToken,
+			StartTimeInclusive: timestamppb.New(start),
+			EndTimeExclusive:   timestamppb.New(end),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var records []*auditlogv1.LogRecord
+		for _, record := range resp.Logs {
+			if !response.Cursor.exported(record) {
+				records = append(records, record)
+			}
+		}
+		if len(records) > 0 {
+			if err := sink.Export(ctx, records); err != nil {
+				return nil, err
+			}
+			for _, record := range records {
+				response.Cursor.advance(record)
+			}
+			response.Exported += len(records)
+			activity.RecordHeartbeat(ctx, response)
+		}
+
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			response.CaughtUp = true
+			return response, nil
+		}
+	}
+	return response, nil
+}
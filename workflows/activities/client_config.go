@@ -0,0 +1,107 @@
+package activities
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"temporal-jumpstart-operations/clientconfig"
+	"temporal-jumpstart-operations/dryrun"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/sdk/temporal"
+)
+
+type WriteClientConfigRequest struct {
+	// OutputPath is the directory temporal.toml is written to
+	OutputPath string `json:"outputPath"`
+	// ApiKeyFile is the plaintext key file the Cloud profiles read their api_key from (optional)
+	ApiKeyFile string `json:"apiKeyFile,omitempty"`
+	// Namespaces are the Cloud namespaces to write a profile for
+	Namespaces []string `json:"namespaces"`
+	// LocalAddress is the host:port of the persistent local TemporalService; a 'local' profile is written when set
+	LocalAddress string `json:"localAddress,omitempty"`
+	// Output selects the formats to generate (optional, defaults to envconfig)
+	Output *clientconfig.OutputOptions `json:"output,omitempty"`
+}
+type WriteClientConfigResponse struct {
+	ConfigFile string   `json:"configFile,omitempty"`
+	Profiles   []string `json:"profiles"`
+	Manifests  []string `json:"manifests,omitempty"`
+}
+
+// WriteClientConfig writes a temporal.toml envconfig file with a profile per Cloud namespace,
+// named after the namespace id, and a 'local' profile for the persistent local TemporalService.
+// The Kubernetes ExternalSecret and kustomize overlay are generated for the first Cloud namespace.
+func (a *Activities) WriteClientConfig(ctx context.Context, args *WriteClientConfigRequest) (*WriteClientConfigResponse, error) {
+	if err := args.Output.Validate(); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if err := a.SecretSinks.CheckDir(args.OutputPath); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if args.ApiKeyFile != "" {
+		if err := a.SecretSinks.CheckDir(filepath.Dir(args.ApiKeyFile)); err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+		}
+	}
+	profiles, err := a.ClientProfiles(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &WriteClientConfigResponse{}
+	for _, p := range profiles {
+		response.Profiles = append(response.Profiles, p.Name)
+	}
+	sort.Strings(response.Profiles)
+
+	if a.DryRun {
+		dryrun.Printf("write client config with profiles %s to %s", strings.Join(response.Profiles, ", "), args.OutputPath)
+		return response, nil
+	}
+	if args.Output.Has(clientconfig.FormatEnvConfig) {
+		if response.ConfigFile, err = clientconfig.Write(args.OutputPath, profiles); err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "failed to write client config", err)
+		}
+	}
+	if len(profiles) > 0 && profiles[0].Name != clientconfig.LocalProfileName {
+		if response.Manifests, err = args.Output.WriteConfigManifests(args.OutputPath, profiles[0]); err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "failed to write client config", err)
+		}
+	}
+	return response, nil
+}
+
+// ClientProfiles resolves the namespace endpoints and builds the envconfig profiles for the request
+func (a *Activities) ClientProfiles(ctx context.Context, args *WriteClientConfigRequest) ([]clientconfig.Profile, error) {
+	apiKeyFile := args.ApiKeyFile
+	if apiKeyFile != "" {
+		if abs, err := filepath.Abs(apiKeyFile); err == nil {
+			apiKeyFile = abs
+		}
+	}
+
+	var profiles []clientconfig.Profile
+	for _, ns := range args.Namespaces {
+		resp, err := a.CloudClient.GetNamespace(ctx, &cloudservicev1.GetNamespaceRequest{
+			Namespace: ns,
+		})
+		if err != nil {
+			return nil, err
+		}
+		profile := clientconfig.CloudProfile(clientconfig.ProfileName(ns), ns, resp.Namespace.GetEndpoints().GetGrpcAddress(), apiKeyFile)
+		// on a dry run the key file was never written
+		if !a.DryRun {
+			if err := profile.ReadApiKey(); err != nil {
+				return nil, err
+			}
+		}
+		profiles = append(profiles, profile)
+	}
+	if args.LocalAddress != "" {
+		profiles = append(profiles, clientconfig.LocalProfile(args.LocalAddress, ""))
+	}
+	return profiles, nil
+}
@@ -0,0 +1,346 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	"go.temporal.io/sdk/temporal"
+)
+
+const (
+	// AuthMethodApiKey authenticates namespace clients with API keys
+	AuthMethodApiKey = "api_key"
+	// AuthMethodMtls authenticates namespace clients with mTLS client certificates
+	AuthMethodMtls = "mtls"
+)
+
+// NamespaceSpec is the serializable form of namespacev1.NamespaceSpec passed between workflows and activities
+type NamespaceSpec struct {
+	// Name is the namespace name without the account suffix (required)
+	Name string `json:"name"`
+	// Regions are the cloud regions the namespace is placed in, e.g. aws-us-east-1 (required)
+	Regions []string `json:"regions"`
+	// RetentionDays is the workflow history retention in days (required)
+	RetentionDays int32 `json:"retentionDays"`
+	// AuthMethod is either api_key or mtls (optional, defaults to api_key)
+	AuthMethod string `json:"authMethod"`
+	// AcceptedClientCA is the PEM encoded CA bundle accepted for mTLS (required for mtls)
+	AcceptedClientCA string `json:"acceptedClientCA,omitempty"`
+	// SearchAttributes maps custom search attribute names to their type, e.g. keyword or int
+	SearchAttributes map[string]string `json:"searchAttributes,omitempty"`
+	// CodecServer configures the codec server the Cloud UI uses to decode payloads (optional)
+	CodecServer *CodecServer `json:"codecServer,omitempty"`
+	// EnableDeleteProtection prevents the namespace from being deleted until protection is disabled
+	EnableDeleteProtection bool `json:"enableDeleteProtection"`
+}
+
+// CodecServer is the serializable form of namespacev1.CodecServerSpec
+type CodecServer struct {
+	Endpoint                      string `json:"endpoint"`
+	PassAccessToken               bool   `json:"passAccessToken"`
+	IncludeCrossOriginCredentials bool   `json:"includeCrossOriginCredentials"`
+}
+
+// NamespaceInfo describes an existing namespace
+type NamespaceInfo struct {
+	Namespace        string            `json:"namespace"`
+	State            string            `json:"state"`
+	Regions          []string          `json:"regions"`
+	RetentionDays    int32             `json:"retentionDays"`
+	AuthMethod       string            `json:"authMethod"`
+	GrpcAddress      string            `json:"grpcAddress"`
+	MtlsGrpcAddress  string            `json:"mtlsGrpcAddress"`
+	WebAddress       string            `json:"webAddress"`
+	SearchAttributes map[string]string `json:"searchAttributes,omitempty"`
+	CodecServer      *CodecServer      `json:"codecServer,omitempty"`
+}
+
+type CreateNamespaceRequest struct {
+	Spec             *NamespaceSpec `json:"spec"`
+	AsyncOperationId string         `json:"asyncOperationId"`
+}
+type CreateNamespaceResponse struct {
+	Namespace        string `json:"namespace"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+type GetNamespaceRequest struct {
+	Namespace string `json:"namespace"`
+}
+type UpdateNamespaceRequest struct {
+	Namespace string `json:"namespace"`
+	// RetentionDays replaces the retention when set
+	RetentionDays *int32 `json:"retentionDays,omitempty"`
+	// AuthMethod switches between api_key and mtls when set
+	AuthMethod string `json:"authMethod,omitempty"`
+	// AcceptedClientCA replaces the mTLS CA bundle when set
+	AcceptedClientCA string `json:"acceptedClientCA,omitempty"`
+	// SearchAttributes are added to the existing custom search attributes
+	SearchAttributes map[string]string `json:"searchAttributes,omitempty"`
+	// CodecServer replaces the codec server settings when set
+	CodecServer      *CodecServer `json:"codecServer,omitempty"`
+	AsyncOperationId string       `json:"asyncOperationId"`
+}
+type DeleteNamespaceRequest struct {
+	Namespace        string `json:"namespace"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+type NamespaceOperationResponse struct {
+	Namespace        string `json:"namespace"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+
+// ParseSearchAttributeType converts a short type name such as keyword or keyword_list into its Cloud enum
+func ParseSearchAttributeType(t string) (namespacev1.NamespaceSpec_SearchAttributeType, error) {
+	v, ok := namespacev1.NamespaceSpec_SearchAttributeType_value["SEARCH_ATTRIBUTE_TYPE_"+strings.ToUpper(t)]
+	if !ok || v == int32(namespacev1.NamespaceSpec_SEARCH_ATTRIBUTE_TYPE_UNSPECIFIED) {
+		return namespacev1.NamespaceSpec_SEARCH_ATTRIBUTE_TYPE_UNSPECIFIED, fmt.Errorf("unknown search attribute type %q", t)
+	}
+	return namespacev1.NamespaceSpec_SearchAttributeType(v), nil
+}
+
+// SearchAttributeTypeName returns the short name of a search attribute type, e.g. "keyword"
+func SearchAttributeTypeName(t namespacev1.NamespaceSpec_SearchAttributeType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "SEARCH_ATTRIBUTE_TYPE_"))
+}
+
+// Validate checks the spec for missing or unknown values
+func (s *NamespaceSpec) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("namespace name is required")
+	}
+	if len(s.Regions) == 0 {
+		return fmt.Errorf("at least one region is required")
+	}
+	if s.RetentionDays <= 0 {
+		return fmt.Errorf("retention days must be positive")
+	}
+	switch s.AuthMethod {
+	case "", AuthMethodApiKey:
+	case AuthMethodMtls:
+		if s.AcceptedClientCA == "" {
+			return fmt.Errorf("accepted client CA certificates are required for mtls auth")
+		}
+	default:
+		return fmt.Errorf("unknown auth method %q, expected %s or %s", s.AuthMethod, AuthMethodApiKey, AuthMethodMtls)
+	}
+	for name, t := range s.SearchAttributes {
+		if _, err := ParseSearchAttributeType(t); err != nil {
+			return fmt.Errorf("search attribute %s: %w", name, err)
+		}
+	}
+	if s.CodecServer != nil && !strings.HasPrefix(s.CodecServer.Endpoint, "https://") {
+		return fmt.Errorf("codec server endpoint must be an https url")
+	}
+	return nil
+}
+
+// ToCloudSpec converts the spec into the Cloud API representation
+func (s *NamespaceSpec) ToCloudSpec() (*namespacev1.NamespaceSpec, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	spec := &namespacev1.NamespaceSpec{
+		Name:             s.Name,
+		Regions:          s.Regions,
+		RetentionDays:    s.RetentionDays,
+		SearchAttributes: map[string]namespacev1.NamespaceSpec_SearchAttributeType{},
+		Lifecycle: &namespacev1.LifecycleSpec{
+			EnableDeleteProtection: s.EnableDeleteProtection,
+		},
+	}
+	applyAuthMethod(spec, s.AuthMethod, s.AcceptedClientCA)
+	for name, t := range s.SearchAttributes {
+		spec.SearchAttributes[name], _ = ParseSearchAttributeType(t)
+	}
+	if s.CodecServer != nil {
+		spec.CodecServer = s.CodecServer.toCloudSpec()
+	}
+	return spec, nil
+}
+
+// applyAuthMethod enables exactly one of api key or mtls auth on the spec
+func applyAuthMethod(spec *namespacev1.NamespaceSpec, authMethod string, acceptedClientCA string) {
+	if authMethod == AuthMethodMtls {
+		spec.ApiKeyAuth = &namespacev1.ApiKeyAuthSpec{Enabled: false}
+		spec.MtlsAuth = &namespacev1.MtlsAuthSpec{
+			Enabled:          true,
+			AcceptedClientCa: []byte(acceptedClientCA),
+		}
+		return
+	}
+	spec.ApiKeyAuth = &namespacev1.ApiKeyAuthSpec{Enabled: true}
+	spec.MtlsAuth = nil
+}
+
+func (c *CodecServer) toCloudSpec() *namespacev1.CodecServerSpec {
+	return &namespacev1.CodecServerSpec{
+		Endpoint:                      c.Endpoint,
+		PassAccessToken:               c.PassAccessToken,
+		IncludeCrossOriginCredentials: c.IncludeCrossOriginCredentials,
+	}
+}
+
+// NamespaceInfoFromCloud converts a Cloud namespace into a NamespaceInfo
+func NamespaceInfoFromCloud(ns *namespacev1.Namespace) *NamespaceInfo {
+	info := &NamespaceInfo{
+		Namespace:        ns.Namespace,
+		State:            ns.State.String(),
+		Regions:          ns.GetSpec().GetRegions(),
+		RetentionDays:    ns.GetSpec().GetRetentionDays(),
+		AuthMethod:       AuthMethodApiKey,
+		GrpcAddress:      ns.GetEndpoints().GetGrpcAddress(),
+		MtlsGrpcAddress:  ns.GetEndpoints().GetMtlsGrpcAddress(),
+		WebAddress:       ns.GetEndpoints().GetWebAddress(),
+		SearchAttributes: map[string]string{},
+	}
+	if ns.GetSpec().GetMtlsAuth().GetEnabled() && !ns.GetSpec().GetApiKeyAuth().GetEnabled() {
+		info.AuthMethod = AuthMethodMtls
+	}
+	for name, t := range ns.GetSpec().GetSearchAttributes() {
+		info.SearchAttributes[name] = SearchAttributeTypeName(t)
+	}
+	if cs := ns.GetSpec().GetCodecServer(); cs.GetEndpoint() != "" {
+		info.CodecServer = &CodecServer{
+			Endpoint:                      cs.Endpoint,
+			PassAccessToken:               cs.PassAccessToken,
+			IncludeCrossOriginCredentials: cs.IncludeCrossOriginCredentials,
+		}
+	}
+	return info
+}
+
+// SortedSearchAttributes returns the search attribute names in lexical order
+func (n *NamespaceInfo) SortedSearchAttributes() []string {
+	names := make([]string, 0, len(n.SearchAttributes))
+	for name := range n.SearchAttributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (a *Activities) CreateNamespace(ctx context.Context, args *CreateNamespaceRequest) (*CreateNamespaceResponse, error) {
+	spec, err := args.Spec.ToCloudSpec()
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+
+	namespaces, err := ListNamespaces(ctx, a.CloudClient)
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range namespaces {
+		if strings.EqualFold(ns.GetSpec().GetName(), spec.Name) {
+			return nil, temporal.NewNonRetryableApplicationError(ERR_ALREADY_EXISTS, "already exists", nil)
+		}
+	}
+
+	resp, err := a.CloudClient.CreateNamespace(ctx, &cloudservicev1.CreateNamespaceRequest{
+		Spec:             spec,
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateNamespaceResponse{
+		Namespace:        resp.Namespace,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+func (a *Activities) GetNamespace(ctx context.Context, args *GetNamespaceRequest) (*NamespaceInfo, error) {
+	resp, err := a.CloudClient.GetNamespace(ctx, &cloudservicev1.GetNamespaceRequest{
+		Namespace: args.Namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NamespaceInfoFromCloud(resp.Namespace), nil
+}
+
+// UpdateNamespace applies the set fields of the request on top of the current namespace spec
+func (a *Activities) UpdateNamespace(ctx context.Context, args *UpdateNamespaceRequest) (*NamespaceOperationResponse, error) {
+	current, err := a.CloudClient.GetNamespace(ctx, &cloudservicev1.GetNamespaceRequest{
+		Namespace: args.Namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spec := current.Namespace.Spec
+	if args.RetentionDays != nil {
+		spec.RetentionDays = *args.RetentionDays
+	}
+	if args.AuthMethod != "" || args.AcceptedClientCA != "" {
+		authMethod := args.AuthMethod
+		if authMethod == "" {
+			authMethod = NamespaceInfoFromCloud(current.Namespace).AuthMethod
+		}
+		acceptedClientCA := args.AcceptedClientCA
+		if acceptedClientCA == "" {
+			acceptedClientCA = string(spec.GetMtlsAuth().GetAcceptedClientCa())
+		}
+		if authMethod == AuthMethodMtls && acceptedClientCA == "" {
+			return nil, temporal.NewNonRetryableApplicationError("accepted client CA certificates are required for mtls auth", "ValidationError", nil)
+		}
+		applyAuthMethod(spec, authMethod, acceptedClientCA)
+	}
+	for name, t := range args.SearchAttributes {
+		saType, err := ParseSearchAttributeType(t)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+		}
+		if spec.SearchAttributes == nil {
+			spec.SearchAttributes = map[string]namespacev1.NamespaceSpec_SearchAttributeType{}
+		}
+		spec.SearchAttributes[name] = saType
+	}
+	if args.CodecServer != nil {
+		spec.CodecServer = args.CodecServer.toCloudSpec()
+	}
+
+	resp, err := a.CloudClient.UpdateNamespace(ctx, &cloudservicev1.UpdateNamespaceRequest{
+		Namespace:        args.Namespace,
+		Spec:             spec,
+		ResourceVersion:  current.Namespace.ResourceVersion,
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &NamespaceOperationResponse{
+		Namespace:        args.Namespace,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+func (a *Activities) DeleteNamespace(ctx context.Context, args *DeleteNamespaceRequest) (*NamespaceOperationResponse, error) {
+	current, err := a.CloudClient.GetNamespace(ctx, &cloudservicev1.GetNamespaceRequest{
+		Namespace: args.Namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if current.Namespace.GetSpec().GetLifecycle().GetEnableDeleteProtection() {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("namespace %s has delete protection enabled", args.Namespace), "ValidationError", nil)
+	}
+
+	resp, err := a.CloudClient.DeleteNamespace(ctx, &cloudservicev1.DeleteNamespaceRequest{
+		Namespace:        args.Namespace,
+		ResourceVersion:  current.Namespace.ResourceVersion,
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &NamespaceOperationResponse{
+		Namespace:        args.Namespace,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
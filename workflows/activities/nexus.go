@@ -0,0 +1,367 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"temporal-jumpstart-operations/dryrun"
+
+	commonv1 "go.temporal.io/api/common/v1"
+	apinexusv1 "go.temporal.io/api/nexus/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	cloudnexusv1 "go.temporal.io/cloud-sdk/api/nexus/v1"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/temporal"
+)
+
+const (
+	// NexusTargetCloud manages Nexus endpoints in Temporal Cloud
+	NexusTargetCloud = "cloud"
+	// NexusTargetLocal manages Nexus endpoints on the Temporal server the worker is connected to
+	NexusTargetLocal = "local"
+)
+
+// NexusEndpointSpec is the serializable description of a Nexus endpoint routing to a worker task queue
+type NexusEndpointSpec struct {
+	// Name is the endpoint name callers reference (required)
+	Name string `json:"name"`
+	// Description is shown in the UI (optional)
+	Description string `json:"description,omitempty"`
+	// TargetNamespace is the namespace whose workers handle the endpoint's operations (required)
+	TargetNamespace string `json:"targetNamespace"`
+	// TargetTaskQueue is the task queue those workers poll (required)
+	TargetTaskQueue string `json:"targetTaskQueue"`
+	// AllowedCallerNamespaces may call the endpoint; only enforced by Temporal Cloud
+	AllowedCallerNamespaces []string `json:"allowedCallerNamespaces,omitempty"`
+}
+
+// NexusEndpointInfo describes an existing Nexus endpoint
+type NexusEndpointInfo struct {
+	Id                      string   `json:"id"`
+	Name                    string   `json:"name"`
+	TargetNamespace         string   `json:"targetNamespace"`
+	TargetTaskQueue         string   `json:"targetTaskQueue"`
+	AllowedCallerNamespaces []string `json:"allowedCallerNamespaces,omitempty"`
+	State                   string   `json:"state,omitempty"`
+}
+
+type CreateNexusEndpointRequest struct {
+	// Target is either cloud or local
+	Target           string             `json:"target"`
+	Spec             *NexusEndpointSpec `json:"spec"`
+	AsyncOperationId string             `json:"asyncOperationId"`
+}
+type DeleteNexusEndpointRequest struct {
+	// Target is either cloud or local
+	Target           string `json:"target"`
+	Name             string `json:"name"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+type NexusEndpointOperationResponse struct {
+	EndpointId string `json:"endpointId"`
+	// AsyncOperationId is empty for local endpoints, which are created synchronously
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+
+// NexusEndpoints manages Nexus endpoints on a single Temporal deployment
+type NexusEndpoints interface {
+	Create(ctx context.Context, spec *NexusEndpointSpec, asyncOperationId string) (*NexusEndpointOperationResponse, error)
+	List(ctx context.Context) ([]*NexusEndpointInfo, error)
+	Delete(ctx context.Context, name string, asyncOperationId string) (*NexusEndpointOperationResponse, error)
+}
+
+// Validate checks the spec for missing values
+func (s *NexusEndpointSpec) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("endpoint name is required")
+	}
+	if s.TargetNamespace == "" {
+		return fmt.Errorf("target namespace is required")
+	}
+	if s.TargetTaskQueue == "" {
+		return fmt.Errorf("target task queue is required")
+	}
+	return nil
+}
+
+// NexusEndpointsFor returns the NexusEndpoints for the target, cloud or local
+func (a *Activities) NexusEndpointsFor(target string) (NexusEndpoints, error) {
+	switch target {
+	case "", NexusTargetCloud:
+		if a.CloudClient == nil {
+			return nil, fmt.Errorf("no cloud client configured for cloud nexus endpoints")
+		}
+		return &CloudNexusEndpoints{CloudClient: a.CloudClient}, nil
+	case NexusTargetLocal:
+		if a.OperatorClient == nil {
+			return nil, fmt.Errorf("no operator client configured for local nexus endpoints")
+		}
+		if a.DryRun {
+			return &dryRunNexusEndpoints{NexusEndpoints: &OperatorNexusEndpoints{OperatorClient: a.OperatorClient}}, nil
+		}
+		return &OperatorNexusEndpoints{OperatorClient: a.OperatorClient}, nil
+	default:
+		return nil, fmt.Errorf("unknown nexus target %q, expected %s or %s", target, NexusTargetCloud, NexusTargetLocal)
+	}
+}
+
+func (a *Activities) CreateNexusEndpoint(ctx context.Context, args *CreateNexusEndpointRequest) (*NexusEndpointOperationResponse, error) {
+	if err := args.Spec.Validate(); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	endpoints, err := a.NexusEndpointsFor(args.Target)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+
+	existing, err := endpoints.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range existing {
+		if !strings.EqualFold(endpoint.Name, args.Spec.Name) {
+			continue
+		}
+		// an endpoint matching the spec is what an earlier attempt created, so creating it again succeeds
+		if !endpoint.Matches(args.Spec, args.Target != NexusTargetLocal) {
+			return nil, temporal.NewNonRetryableApplicationError(ERR_ALREADY_EXISTS, "already exists", nil)
+		}
+		return &NexusEndpointOperationResponse{EndpointId: endpoint.Id}, nil
+	}
+
+	return endpoints.Create(ctx, args.Spec, asyncOperationId(ctx, args.AsyncOperationId))
+}
+
+// Matches reports whether the endpoint routes to the target of the spec and, when callers are enforced,
+// admits the same caller namespaces. Local servers do not record the caller namespaces.
+func (e *NexusEndpointInfo) Matches(spec *NexusEndpointSpec, enforcesCallers bool) bool {
+	return e.TargetNamespace == spec.TargetNamespace &&
+		e.TargetTaskQueue == spec.TargetTaskQueue &&
+		(!enforcesCallers || slices.Equal(slices.Sorted(slices.Values(e.AllowedCallerNamespaces)), slices.Sorted(slices.Values(spec.AllowedCallerNamespaces))))
+}
+
+func (a *Activities) DeleteNexusEndpoint(ctx context.Context, args *DeleteNexusEndpointRequest) (*NexusEndpointOperationResponse, error) {
+	endpoints, err := a.NexusEndpointsFor(args.Target)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	return endpoints.Delete(ctx, args.Name, args.AsyncOperationId)
+}
+
+// nexusDescription encodes the description the way the Temporal CLI does
+func nexusDescription(description string) (*commonv1.Payload, error) {
+	if description == "" {
+		return nil, nil
+	}
+	return converter.GetDefaultDataConverter().ToPayload(description)
+}
+
+// CloudNexusEndpoints manages Nexus endpoints through the Temporal Cloud API
+type CloudNexusEndpoints struct {
+	CloudClient cloudservicev1.CloudServiceClient
+}
+
+func (c *CloudNexusEndpoints) Create(ctx context.Context, spec *NexusEndpointSpec, asyncOperationId string) (*NexusEndpointOperationResponse, error) {
+	description, err := nexusDescription(spec.Description)
+	if err != nil {
+		return nil, err
+	}
+	cloudSpec := &cloudnexusv1.EndpointSpec{
+		Name: spec.Name,
+		TargetSpec: &cloudnexusv1.EndpointTargetSpec{
+			Variant: &cloudnexusv1.EndpointTargetSpec_WorkerTargetSpec{
+				WorkerTargetSpec: &cloudnexusv1.WorkerTargetSpec{
+					NamespaceId: spec.TargetNamespace,
+					TaskQueue:   spec.TargetTaskQueue,
+				},
+			},
+		},
+		Description: description,
+	}
+	for _, ns := range spec.AllowedCallerNamespaces {
+		cloudSpec.PolicySpecs = append(cloudSpec.PolicySpecs, &cloudnexusv1.EndpointPolicySpec{
+			Variant: &cloudnexusv1.EndpointPolicySpec_AllowedCloudNamespacePolicySpec{
+				AllowedCloudNamespacePolicySpec: &cloudnexusv1.AllowedCloudNamespacePolicySpec{NamespaceId: ns},
+			},
+		})
+	}
+
+	resp, err := c.CloudClient.CreateNexusEndpoint(ctx, &cloudservicev1.CreateNexusEndpointRequest{
+		Spec:             cloudSpec,
+		AsyncOperationId: asyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &NexusEndpointOperationResponse{
+		EndpointId:       resp.EndpointId,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+func (c *CloudNexusEndpoints) List(ctx context.Context) ([]*NexusEndpointInfo, error) {
+	var result []*NexusEndpointInfo
+	pageToken := ""
+	for {
+		resp, err := c.CloudClient.GetNexusEndpoints(ctx, &cloudservicev1.GetNexusEndpointsRequest{
+			PageSize:  maxPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, endpoint := range resp.Endpoints {
+			info := &NexusEndpointInfo{
+				Id:              endpoint.Id,
+				Name:            endpoint.GetSpec().GetName(),
+				TargetNamespace: endpoint.GetSpec().GetTargetSpec().GetWorkerTargetSpec().GetNamespaceId(),
+				TargetTaskQueue: endpoint.GetSpec().GetTargetSpec().GetWorkerTargetSpec().GetTaskQueue(),
+				State:           endpoint.State.String(),
+			}
+			for _, policy := range endpoint.GetSpec().GetPolicySpecs() {
+				if ns := policy.GetAllowedCloudNamespacePolicySpec().GetNamespaceId(); ns != "" {
+					info.AllowedCallerNamespaces = append(info.AllowedCallerNamespaces, ns)
+				}
+			}
+			result = append(result, info)
+		}
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			return result, nil
+		}
+	}
+}
+
+func (c *CloudNexusEndpoints) Delete(ctx context.Context, name string, asyncOperationId string) (*NexusEndpointOperationResponse, error) {
+	resp, err := c.CloudClient.GetNexusEndpoints(ctx, &cloudservicev1.GetNexusEndpointsRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range resp.Endpoints {
+		if endpoint.GetSpec().GetName() != name {
+			continue
+		}
+		deleted, err := c.CloudClient.DeleteNexusEndpoint(ctx, &cloudservicev1.DeleteNexusEndpointRequest{
+			EndpointId:       endpoint.Id,
+			ResourceVersion:  endpoint.ResourceVersion,
+			AsyncOperationId: asyncOperationId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &NexusEndpointOperationResponse{
+			EndpointId:       endpoint.Id,
+			AsyncOperationId: deleted.GetAsyncOperation().GetId(),
+		}, nil
+	}
+	return nil, temporal.NewNonRetryableApplicationError(ERR_NOT_FOUND, "not found", fmt.Errorf("nexus endpoint %s", name))
+}
+
+// OperatorNexusEndpoints manages Nexus endpoints through the OperatorService of a
+// self-hosted or dev server, such as the local TemporalService
+type OperatorNexusEndpoints struct {
+	OperatorClient operatorservice.OperatorServiceClient
+}
+
+func (o *OperatorNexusEndpoints) Create(ctx context.Context, spec *NexusEndpointSpec, asyncOperationId string) (*NexusEndpointOperationResponse, error) {
+	description, err := nexusDescription(spec.Description)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.OperatorClient.CreateNexusEndpoint(ctx, &operatorservice.CreateNexusEndpointRequest{
+		Spec: &apinexusv1.EndpointSpec{
+			Name:        spec.Name,
+			Description: description,
+			Target: &apinexusv1.EndpointTarget{
+				Variant: &apinexusv1.EndpointTarget_Worker_{
+					Worker: &apinexusv1.EndpointTarget_Worker{
+						Namespace: spec.TargetNamespace,
+						TaskQueue: spec.TargetTaskQueue,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &NexusEndpointOperationResponse{
+		EndpointId: resp.GetEndpoint().GetId(),
+	}, nil
+}
+
+func (o *OperatorNexusEndpoints) List(ctx context.Context) ([]*NexusEndpointInfo, error) {
+	var result []*NexusEndpointInfo
+	var pageToken []byte
+	for {
+		resp, err := o.OperatorClient.ListNexusEndpoints(ctx, &operatorservice.ListNexusEndpointsRequest{
+			PageSize:      maxPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, endpoint := range resp.Endpoints {
+			result = append(result, &NexusEndpointInfo{
+				Id:              endpoint.Id,
+				Name:            endpoint.GetSpec().GetName(),
+				TargetNamespace: endpoint.GetSpec().GetTarget().GetWorker().GetNamespace(),
+				TargetTaskQueue: endpoint.GetSpec().GetTarget().GetWorker().GetTaskQueue(),
+			})
+		}
+		if pageToken = resp.NextPageToken; len(pageToken) == 0 {
+			return result, nil
+		}
+	}
+}
+
+func (o *OperatorNexusEndpoints) Delete(ctx context.Context, name string, asyncOperationId string) (*NexusEndpointOperationResponse, error) {
+	resp, err := o.OperatorClient.ListNexusEndpoints(ctx, &operatorservice.ListNexusEndpointsRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range resp.Endpoints {
+		if endpoint.GetSpec().GetName() != name {
+			continue
+		}
+		if _, err := o.OperatorClient.DeleteNexusEndpoint(ctx, &operatorservice.DeleteNexusEndpointRequest{
+			Id:      endpoint.Id,
+			Version: endpoint.Version,
+		}); err != nil {
+			return nil, err
+		}
+		return &NexusEndpointOperationResponse{EndpointId: endpoint.Id}, nil
+	}
+	return nil, temporal.NewNonRetryableApplicationError(ERR_NOT_FOUND, "not found", fmt.Errorf("nexus endpoint %s", name))
+}
+
+// dryRunNexusEndpoints lists the endpoints of a deployment the Cloud client dry run cannot reach, and only
+// describes creating and deleting them
+type dryRunNexusEndpoints struct {
+	NexusEndpoints
+}
+
+func (d *dryRunNexusEndpoints) Create(ctx context.Context, spec *NexusEndpointSpec, asyncOperationId string) (*NexusEndpointOperationResponse, error) {
+	dryrun.Printf("create local nexus endpoint %s targeting %s/%s", spec.Name, spec.TargetNamespace, spec.TargetTaskQueue)
+	return &NexusEndpointOperationResponse{EndpointId: dryrun.IDPrefix + "endpoint"}, nil
+}
+
+func (d *dryRunNexusEndpoints) Delete(ctx context.Context, name string, asyncOperationId string) (*NexusEndpointOperationResponse, error) {
+	endpoints, err := d.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.Name == name {
+			dryrun.Printf("delete local nexus endpoint %s (%s)", name, endpoint.Id)
+			return &NexusEndpointOperationResponse{EndpointId: endpoint.Id}, nil
+		}
+	}
+	return nil, temporal.NewNonRetryableApplicationError(ERR_NOT_FOUND, "not found", fmt.Errorf("nexus endpoint %s", name))
+}
@@ -0,0 +1,32 @@
+package activities
+
+import (
+	"context"
+
+	"temporal-jumpstart-operations/dryrun"
+	"temporal-jumpstart-operations/notify"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+// NotifyRequest carries the outcome to tell teams about
+type NotifyRequest struct {
+	Event *notify.Event `json:"event"`
+}
+
+// Notify renders the event with the worker's templates and delivers it to the worker's notifiers.
+// The notifier configuration stays on the worker so webhook URLs and credentials never enter workflow history.
+func (a *Activities) Notify(ctx context.Context, args *NotifyRequest) error {
+	if a.Notifier == nil || args.Event == nil {
+		return nil
+	}
+	message, err := a.NotifyTemplates.Render(args.Event)
+	if err != nil {
+		return temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if a.DryRun {
+		dryrun.Printf("notify %q", message.Title)
+		return nil
+	}
+	return a.Notifier.Notify(ctx, message)
+}
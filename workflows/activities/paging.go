@@ -0,0 +1,129 @@
+package activities
+
+import (
+	"context"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+)
+
+// maxPageSize is the page size list calls ask for; the Cloud API documents that page_size cannot exceed 1000
+const maxPageSize = 1000
+
+// ListServiceAccounts pages through every service account in the account
+func ListServiceAccounts(ctx context.Context, cloudClient cloudservicev1.CloudServiceClient) ([]*identityv1.ServiceAccount, error) {
+	var result []*identityv1.ServiceAccount
+	pageToken := ""
+	for {
+		resp, err := cloudClient.GetServiceAccounts(ctx, &cloudservicev1.GetServiceAccountsRequest{
+			PageSize:  maxPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.ServiceAccount...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			return result, nil
+		}
+	}
+}
+
+// ListApiKeys pages through every API key owned by the given owner type.
+// OWNER_TYPE_UNSPECIFIED returns keys of all owners.
+func ListApiKeys(ctx context.Context, cloudClient cloudservicev1.CloudServiceClient, ownerType identityv1.OwnerType) ([]*identityv1.ApiKey, error) {
+	var result []*identityv1.ApiKey
+	pageToken := ""
+	for {
+		resp, err := cloudClient.GetApiKeys(ctx, &cloudservicev1.GetApiKeysRequest{
+			PageSize:  maxPageSize,
+			PageToken: pageToken,
+			OwnerType: ownerType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.ApiKeys...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			return result, nil
+		}
+	}
+}
+
+// ListNamespaces pages through every namespace in the account
+func ListNamespaces(ctx context.Context, cloudClient cloudservicev1.CloudServiceClient) ([]*namespacev1.Namespace, error) {
+	var result []*namespacev1.Namespace
+	pageToken := ""
+	for {
+		resp, err := cloudClient.GetNamespaces(ctx, &cloudservicev1.GetNamespacesRequest{
+			PageSize:  maxPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Namespaces...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			return result, nil
+		}
+	}
+}
+
+// ListUsers pages through every user in the account
+func ListUsers(ctx context.Context, cloudClient cloudservicev1.CloudServiceClient) ([]*identityv1.User, error) {
+	var result []*identityv1.User
+	pageToken := ""
+	for {
+		resp, err := cloudClient.GetUsers(ctx, &cloudservicev1.GetUsersRequest{
+			PageSize:  maxPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Users...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			return result, nil
+		}
+	}
+}
+
+// ListUserGroups pages through every user group in the account
+func ListUserGroups(ctx context.Context, cloudClient cloudservicev1.CloudServiceClient) ([]*identityv1.UserGroup, error) {
+	var result []*identityv1.UserGroup
+	pageToken := ""
+	for {
+		resp, err := cloudClient.GetUserGroups(ctx, &cloudservicev1.GetUserGroupsRequest{
+			PageSize:  maxPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Groups...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			return result, nil
+		}
+	}
+}
+
+// ListUserGroupMembers pages through every member of a user group
+func ListUserGroupMembers(ctx context.Context, cloudClient cloudservicev1.CloudServiceClient, groupId string) ([]*identityv1.UserGroupMember, error) {
+	var result []*identityv1.UserGroupMember
+	pageToken := ""
+	for {
+		resp, err := cloudClient.GetUserGroupMembers(ctx, &cloudservicev1.GetUserGroupMembersRequest{
+			PageSize:  maxPageSize,
+			PageToken: pageToken,
+			GroupId:   groupId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Members...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/sdk/temporal"
+)
+
+const ERR_NOT_FOUND = "not found"
+
+type InviteUserRequest struct {
+	Email            string  `json:"email"`
+	Access           *Access `json:"access,omitempty"`
+	AsyncOperationId string  `json:"asyncOperationId"`
+}
+type SetUserAccessRequest struct {
+	Email string `json:"email"`
+	// Access updates the user's access; without an account role the user keeps its current one
+	Access *Access `json:"access,omitempty"`
+	// ReplaceNamespaces drops the namespace permissions Access does not list instead of keeping them
+	ReplaceNamespaces bool   `json:"replaceNamespaces,omitempty"`
+	AsyncOperationId  string `json:"asyncOperationId"`
+}
+type RemoveUserRequest struct {
+	Email            string `json:"email"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+type UserOperationResponse struct {
+	UserId           string `json:"userId"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+type CreateUserGroupRequest struct {
+	DisplayName      string  `json:"displayName"`
+	Access           *Access `json:"access,omitempty"`
+	AsyncOperationId string  `json:"asyncOperationId"`
+}
+type DeleteUserGroupRequest struct {
+	DisplayName      string `json:"displayName"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+type UserGroupMemberRequest struct {
+	GroupName        string `json:"groupName"`
+	Email            string `json:"email"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+type GetUserGroupMembersRequest struct {
+	GroupName string `json:"groupName"`
+}
+type UserGroupOperationResponse struct {
+	GroupId          string `json:"groupId"`
+	AsyncOperationId string `json:"asyncOperationId"`
+}
+
+// InviteUser creates a Cloud user, which sends the invitation email
+func (a *Activities) InviteUser(ctx context.Context, args *InviteUserRequest) (*UserOperationResponse, error) {
+	if _, err := a.findUserByEmail(ctx, args.Email); err == nil {
+		return nil, temporal.NewNonRetryableApplicationError(ERR_ALREADY_EXISTS, "already exists", nil)
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+
+	access, err := args.Access.ToIdentityAccess()
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	resp, err := a.CloudClient.CreateUser(ctx, &cloudservicev1.CreateUserRequest{
+		Spec: &identityv1.UserSpec{
+			Email:  args.Email,
+			Access: access,
+		},
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UserOperationResponse{
+		UserId:           resp.UserId,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+// SetUserAccess updates the account role and namespace permissions of a user. The namespace permissions
+// are merged into the current ones unless ReplaceNamespaces is set.
+func (a *Activities) SetUserAccess(ctx context.Context, args *SetUserAccessRequest) (*UserOperationResponse, error) {
+	user, err := a.findUserByEmail(ctx, args.Email)
+	if err != nil {
+		return nil, err
+	}
+	access, err := args.Access.ToIdentityAccess()
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if args.Access == nil || args.Access.AccountRole == "" {
+		access.AccountAccess = user.GetSpec().GetAccess().GetAccountAccess()
+	}
+	if !args.ReplaceNamespaces {
+		for ns, current := range user.GetSpec().GetAccess().GetNamespaceAccesses() {
+			if _, ok := access.NamespaceAccesses[ns]; !ok {
+				access.NamespaceAccesses[ns] = current
+			}
+		}
+	}
+	resp, err := a.CloudClient.UpdateUser(ctx, &cloudservicev1.UpdateUserRequest{
+		UserId: user.Id,
+		Spec: &identityv1.UserSpec{
+			Email:  user.GetSpec().GetEmail(),
+			Access: access,
+		},
+		ResourceVersion:  user.ResourceVersion,
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UserOperationResponse{
+		UserId:           user.Id,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+// RemoveUser deletes a Cloud user
+func (a *Activities) RemoveUser(ctx context.Context, args *RemoveUserRequest) (*UserOperationResponse, error) {
+	user, err := a.findUserByEmail(ctx, args.Email)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.CloudClient.DeleteUser(ctx, &cloudservicev1.DeleteUserRequest{
+		UserId:           user.Id,
+		ResourceVersion:  user.ResourceVersion,
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UserOperationResponse{
+		UserId:           user.Id,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+// CreateUserGroup creates a Cloud managed user group
+func (a *Activities) CreateUserGroup(ctx context.Context, args *CreateUserGroupRequest) (*UserGroupOperationResponse, error) {
+	if _, err := a.findUserGroupByName(ctx, args.DisplayName); err == nil {
+		return nil, temporal.NewNonRetryableApplicationError(ERR_ALREADY_EXISTS, "already exists", nil)
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+
+	access, err := args.Access.ToIdentityAccess()
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	resp, err := a.CloudClient.CreateUserGroup(ctx, &cloudservicev1.CreateUserGroupRequest{
+		Spec: &identityv1.UserGroupSpec{
+			DisplayName: args.DisplayName,
+			Access:      access,
+			GroupType: &identityv1.UserGroupSpec_CloudGroup{
+				CloudGroup: &identityv1.CloudGroupSpec{},
+			},
+		},
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UserGroupOperationResponse{
+		GroupId:          resp.GroupId,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+// DeleteUserGroup deletes a user group; its members keep their own access
+func (a *Activities) DeleteUserGroup(ctx context.Context, args *DeleteUserGroupRequest) (*UserGroupOperationResponse, error) {
+	group, err := a.findUserGroupByName(ctx, args.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.CloudClient.DeleteUserGroup(ctx, &cloudservicev1.DeleteUserGroupRequest{
+		GroupId:          group.Id,
+		ResourceVersion:  group.ResourceVersion,
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UserGroupOperationResponse{
+		GroupId:          group.Id,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+// AddUserGroupMember adds the user with the given email to the group; a user that is already a member is left alone
+func (a *Activities) AddUserGroupMember(ctx context.Context, args *UserGroupMemberRequest) (*UserGroupOperationResponse, error) {
+	group, user, err := a.findGroupAndUser(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	members, err := ListUserGroupMembers(ctx, a.CloudClient, group.Id)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(members, func(member *identityv1.UserGroupMember) bool {
+		return member.GetMemberId().GetUserId() == user.Id
+	}) {
+		return &UserGroupOperationResponse{GroupId: group.Id}, nil
+	}
+	resp, err := a.CloudClient.AddUserGroupMember(ctx, &cloudservicev1.AddUserGroupMemberRequest{
+		GroupId:          group.Id,
+		MemberId:         &identityv1.UserGroupMemberId{MemberType: &identityv1.UserGroupMemberId_UserId{UserId: user.Id}},
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UserGroupOperationResponse{
+		GroupId:          group.Id,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+// RemoveUserGroupMember removes the user with the given email from the group
+func (a *Activities) RemoveUserGroupMember(ctx context.Context, args *UserGroupMemberRequest) (*UserGroupOperationResponse, error) {
+	group, user, err := a.findGroupAndUser(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.CloudClient.RemoveUserGroupMember(ctx, &cloudservicev1.RemoveUserGroupMemberRequest{
+		GroupId:          group.Id,
+		MemberId:         &identityv1.UserGroupMemberId{MemberType: &identityv1.UserGroupMemberId_UserId{UserId: user.Id}},
+		AsyncOperationId: args.AsyncOperationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UserGroupOperationResponse{
+		GroupId:          group.Id,
+		AsyncOperationId: resp.GetAsyncOperation().GetId(),
+	}, nil
+}
+
+// GetUserGroupMembers returns the emails of the users in the group
+func (a *Activities) GetUserGroupMembers(ctx context.Context, args *GetUserGroupMembersRequest) ([]string, error) {
+	group, err := a.findUserGroupByName(ctx, args.GroupName)
+	if err != nil {
+		return nil, err
+	}
+	members, err := ListUserGroupMembers(ctx, a.CloudClient, group.Id)
+	if err != nil {
+		return nil, err
+	}
+	users, err := ListUsers(ctx, a.CloudClient)
+	if err != nil {
+		return nil, err
+	}
+	emails := map[string]string{}
+	for _, user := range users {
+		emails[user.Id] = user.GetSpec().GetEmail()
+	}
+
+	var result []string
+	for _, member := range members {
+		userId := member.GetMemberId().GetUserId()
+		if email, ok := emails[userId]; ok {
+			result = append(result, email)
+		} else {
+			result = append(result, userId)
+		}
+	}
+	return result, nil
+}
+
+func (a *Activities) findGroupAndUser(ctx context.Context, args *UserGroupMemberRequest) (*identityv1.UserGroup, *identityv1.User, error) {
+	group, err := a.findUserGroupByName(ctx, args.GroupName)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := a.findUserByEmail(ctx, args.Email)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, user, nil
+}
+
+// findUserByEmail returns the user with the email or a non-retryable ERR_NOT_FOUND error
+func (a *Activities) findUserByEmail(ctx context.Context, email string) (*identityv1.User, error) {
+	resp, err := a.CloudClient.GetUsers(ctx, &cloudservicev1.GetUsersRequest{
+		Email: email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range resp.Users {
+		if strings.EqualFold(user.GetSpec().GetEmail(), email) {
+			return user, nil
+		}
+	}
+	return nil, temporal.NewNonRetryableApplicationError(ERR_NOT_FOUND, "not found", fmt.Errorf("user %s", email))
+}
+
+// findUserGroupByName returns the group with the display name or a non-retryable ERR_NOT_FOUND error
+func (a *Activities) findUserGroupByName(ctx context.Context, displayName string) (*identityv1.UserGroup, error) {
+	resp, err := a.CloudClient.GetUserGroups(ctx, &cloudservicev1.GetUserGroupsRequest{
+		DisplayName: displayName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range resp.Groups {
+		if strings.EqualFold(group.GetSpec().GetDisplayName(), displayName) {
+			return group, nil
+		}
+	}
+	return nil, temporal.NewNonRetryableApplicationError(ERR_NOT_FOUND, "not found", fmt.Errorf("user group %s", displayName))
+}
+
+func isNotFound(err error) bool {
+	var appErr *temporal.ApplicationError
+	return errors.As(err, &appErr) && appErr.Type() == "not found"
+}
@@ -0,0 +1,198 @@
+package workflows
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"temporal-jumpstart-operations/policy"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Names of the approval handlers every gated workflow registers
+const (
+	// ApproveUpdate signs off a pending operation with an ApprovalDecision
+	ApproveUpdate = "approve"
+	// RejectUpdate rejects a pending operation with an ApprovalDecision
+	RejectUpdate = "reject"
+	// ApprovalQuery returns the ApprovalState
+	ApprovalQuery = "approval"
+	// ApprovalMemoKey is the memo field the ApprovalState is kept in, so pending approvals can be listed without a worker
+	ApprovalMemoKey = "approval"
+)
+
+// Approval statuses
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+	ApprovalExpired  = "expired"
+)
+
+// ApprovalDecision is the argument of the approve and reject updates
+type ApprovalDecision struct {
+	// Approver is the identity of the person deciding, verified from their 'tcld login' token by the CLI (required)
+	Approver string `json:"approver"`
+	// AccountID is the Cloud account the approver's token belongs to (required)
+	AccountID string `json:"accountId"`
+	// Comment explains the decision (optional)
+	Comment string `json:"comment,omitempty"`
+	// DecidedAt is set by the workflow when the decision is accepted
+	DecidedAt time.Time `json:"decidedAt"`
+}
+
+// ApprovalState is the progress of the sign-off of a privileged operation
+type ApprovalState struct {
+	// Operation describes what is being approved
+	Operation string `json:"operation"`
+	// RequestedBy is the identity that started the operation; it cannot approve it
+	RequestedBy string `json:"requestedBy"`
+	// Required is the number of approvals needed
+	Required int `json:"required"`
+	// Approvals are the approvals so far
+	Approvals []ApprovalDecision `json:"approvals"`
+	// Rejection is set when the operation was rejected
+	Rejection *ApprovalDecision `json:"rejection,omitempty"`
+	// Deadline is when the operation is rejected if it is still pending
+	Deadline time.Time `json:"deadline"`
+	// Status is pending, approved, rejected or expired
+	Status string `json:"status"`
+}
+
+// ApprovalRequest is what the workflow input of a privileged operation says about its sign-off
+type ApprovalRequest struct {
+	// RequestedBy is the verified 'tcld login' subject of who started the operation; they cannot approve it
+	RequestedBy string `json:"requestedBy,omitempty"`
+	// RequireApproval is set when the starter's policy marks the Cloud account as production. The workflow then
+	// fails rather than run unapproved on a worker whose policy has no approval rules for the account.
+	RequireApproval bool `json:"requireApproval,omitempty"`
+}
+
+// awaitApproval blocks until the approval the worker's policy requires on its Cloud account is granted by enough
+// approvers, and fails with a non-retryable ApprovalRejected error when someone rejects it or the timeout passes.
+// Operations on accounts that are not production pass immediately.
+func awaitApproval(ctx workflow.Context, request ApprovalRequest, operation string) error {
+	var requirement *policy.ApprovalRequirement
+	if err := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, defaultActivityOptions),
+		policy.TypeActivities.ResolveApproval).Get(ctx, &requirement); err != nil {
+		return err
+	}
+	approval := requirement.Approval
+	if approval == nil {
+		if request.RequireApproval {
+			return temporal.NewNonRetryableApplicationError(fmt.Sprintf(
+				"%s needs approval on account %s but the worker's policy has no approval rules for it", operation, requirement.AccountID),
+				"ApprovalRequired", nil)
+		}
+		return nil
+	}
+	if request.RequestedBy == "" {
+		return temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("%s needs approval, start it as a 'tcld login' user so approvers can be told apart from the requester", operation),
+			"ApprovalRequired", nil)
+	}
+	required := max(approval.Approvers, 1)
+	timeout := 24 * time.Hour
+	if approval.Timeout != "" {
+		timeout, _ = activities.ParseDuration(approval.Timeout)
+	}
+
+	logger := workflow.GetLogger(ctx)
+	state := &ApprovalState{
+		Operation:   operation,
+		RequestedBy: request.RequestedBy,
+		Required:    required,
+		Deadline:    workflow.Now(ctx).Add(timeout),
+		Status:      ApprovalPending,
+	}
+	publish := func() {
+		if err := workflow.UpsertMemo(ctx, map[string]interface{}{ApprovalMemoKey: state}); err != nil {
+			logger.Warn("Failed to publish approval state", "error", err)
+		}
+	}
+
+	validate := func(decision *ApprovalDecision) error {
+		switch {
+		case state.Status != ApprovalPending:
+			return fmt.Errorf("operation is already %s", state.Status)
+		case decision == nil || decision.Approver == "":
+			return fmt.Errorf("an approver identity is required")
+		case decision.AccountID != requirement.AccountID:
+			return fmt.Errorf("%s belongs to account %s, not %s", decision.Approver, decision.AccountID, requirement.AccountID)
+		case strings.EqualFold(decision.Approver, state.RequestedBy):
+			return fmt.Errorf("%s requested the operation and cannot decide on it", decision.Approver)
+		case len(approval.AllowedApprovers) > 0 && !slices.ContainsFunc(approval.AllowedApprovers, func(allowed string) bool {
+			return strings.EqualFold(allowed, decision.Approver)
+		}):
+			return fmt.Errorf("%s is not an allowed approver", decision.Approver)
+		}
+		return nil
+	}
+
+	if err := workflow.SetQueryHandler(ctx, ApprovalQuery, func() (*ApprovalState, error) {
+		return state, nil
+	}); err != nil {
+		return err
+	}
+	if err := workflow.SetUpdateHandlerWithOptions(ctx, ApproveUpdate, func(ctx workflow.Context, decision *ApprovalDecision) (*ApprovalState, error) {
+		decision.DecidedAt = workflow.Now(ctx)
+		state.Approvals = append(state.Approvals, *decision)
+		if len(state.Approvals) >= state.Required {
+			state.Status = ApprovalApproved
+		}
+		logger.Info("Operation approved", "approver", decision.Approver, "approvals", len(state.Approvals), "required", state.Required)
+		publish()
+		return state, nil
+	}, workflow.UpdateHandlerOptions{
+		Validator: func(ctx workflow.Context, decision *ApprovalDecision) error {
+			if err := validate(decision); err != nil {
+				return err
+			}
+			if slices.ContainsFunc(state.Approvals, func(a ApprovalDecision) bool { return strings.EqualFold(a.Approver, decision.Approver) }) {
+				return fmt.Errorf("%s already approved the operation", decision.Approver)
+			}
+			return nil
+		},
+	}); err != nil {
+		return err
+	}
+	if err := workflow.SetUpdateHandlerWithOptions(ctx, RejectUpdate, func(ctx workflow.Context, decision *ApprovalDecision) (*ApprovalState, error) {
+		decision.DecidedAt = workflow.Now(ctx)
+		state.Rejection = decision
+		state.Status = ApprovalRejected
+		logger.Info("Operation rejected", "approver", decision.Approver)
+		publish()
+		return state, nil
+	}, workflow.UpdateHandlerOptions{
+		Validator: func(ctx workflow.Context, decision *ApprovalDecision) error {
+			return validate(decision)
+		},
+	}); err != nil {
+		return err
+	}
+
+	logger.Info("Waiting for approval", "operation", operation, "required", required, "deadline", state.Deadline)
+	publish()
+	decided, err := workflow.AwaitWithTimeout(ctx, timeout, func() bool { return state.Status != ApprovalPending })
+	if err != nil {
+		return err
+	}
+	if !decided {
+		state.Status = ApprovalExpired
+		publish()
+		return temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("%s was not approved within %s", operation, timeout), "ApprovalRejected", nil, state)
+	}
+	if state.Status == ApprovalRejected {
+		message := fmt.Sprintf("%s was rejected by %s", operation, state.Rejection.Approver)
+		if state.Rejection.Comment != "" {
+			message += ": " + state.Rejection.Comment
+		}
+		return temporal.NewNonRetryableApplicationError(message, "ApprovalRejected", nil, state)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package workflows
+
+import (
+	"time"
+
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// defaultActivityOptions are applied to every Cloud API activity the operations workflows execute
+var defaultActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: time.Minute,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2,
+		MaximumInterval:    30 * time.Second,
+		MaximumAttempts:    5,
+	},
+}
+
+// awaitAsyncOperation polls CheckOperationCompletion until the Cloud async operation is fulfilled.
+// CheckOperationCompletion fails with a retryable error while the operation is still in flight,
+// so the retry policy doubles as the polling loop.
+func awaitAsyncOperation(ctx workflow.Context, asyncOperationId string) error {
+	if asyncOperationId == "" {
+		return nil
+	}
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout:    30 * time.Second,
+		ScheduleToCloseTimeout: 30 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    2 * time.Second,
+			BackoffCoefficient: 1.5,
+			MaximumInterval:    time.Minute,
+		},
+	})
+	return workflow.ExecuteActivity(ctx, activities.TypeActivities.CheckOperationCompletion, &activities.CheckOperationCompletionRequest{
+		AsyncOperationId: asyncOperationId,
+	}).Get(ctx, nil)
+}
@@ -0,0 +1,69 @@
+package workflows
+
+import (
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+type CreateNamespaceState struct {
+	Args      *CreateNamespaceRequest
+	Created   *activities.CreateNamespaceResponse
+	Namespace *activities.NamespaceInfo
+}
+
+// CreateNamespaceRequest represents the parameters for provisioning a namespace
+type CreateNamespaceRequest struct {
+	// Spec describes the namespace to create (required)
+	Spec *activities.NamespaceSpec `json:"spec"`
+}
+
+// CreateNamespace is a Temporal workflow that provisions a Temporal Cloud namespace
+// and waits until it is ready to accept clients
+func CreateNamespace(ctx workflow.Context, args *CreateNamespaceRequest) (*activities.NamespaceInfo, error) {
+	state := &CreateNamespaceState{
+		Args: args,
+	}
+
+	// Validate required fields
+	if args.Spec == nil {
+		return nil, temporal.NewNonRetryableApplicationError("spec is required", "ValidationError", nil)
+	}
+	if args.Spec.AuthMethod == "" {
+		args.Spec.AuthMethod = activities.AuthMethodApiKey
+	}
+	if err := args.Spec.Validate(); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions)
+	workflow.GetLogger(ctx).Info("CreateNamespace workflow started",
+		"name", args.Spec.Name,
+		"regions", args.Spec.Regions,
+		"retentionDays", args.Spec.RetentionDays,
+		"authMethod", args.Spec.AuthMethod,
+	)
+
+	if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.CreateNamespace, &activities.CreateNamespaceRequest{
+		Spec: args.Spec,
+	}).Get(ctx, &state.Created); err != nil {
+		return nil, err
+	}
+	if err := awaitAsyncOperation(ctx, state.Created.AsyncOperationId); err != nil {
+		return nil, err
+	}
+
+	// endpoints are only known once the namespace is active
+	if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.GetNamespace, &activities.GetNamespaceRequest{
+		Namespace: state.Created.Namespace,
+	}).Get(ctx, &state.Namespace); err != nil {
+		return nil, err
+	}
+
+	workflow.GetLogger(ctx).Info("CreateNamespace workflow completed successfully",
+		"namespace", state.Namespace.Namespace,
+		"grpcAddress", state.Namespace.GrpcAddress,
+	)
+	return state.Namespace, nil
+}
@@ -0,0 +1,80 @@
+package workflows
+
+import (
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+type CreateNexusEndpointState struct {
+	Args    *CreateNexusEndpointRequest
+	Created *activities.NexusEndpointOperationResponse
+}
+
+// CreateNexusEndpointRequest represents the parameters for creating a Nexus endpoint
+type CreateNexusEndpointRequest struct {
+	// Target is where the endpoint is created: cloud or local (optional, defaults to 'cloud')
+	Target string `json:"target"`
+
+	// Spec describes the endpoint and the namespace and task queue it routes to (required)
+	Spec *activities.NexusEndpointSpec `json:"spec"`
+}
+
+// CreateNexusEndpoint is a Temporal workflow that creates a Nexus endpoint targeting a namespace
+// and task queue, either in Temporal Cloud or on the Temporal server running the workflow
+func CreateNexusEndpoint(ctx workflow.Context, args *CreateNexusEndpointRequest) (*activities.NexusEndpointOperationResponse, error) {
+	state := &CreateNexusEndpointState{
+		Args: args,
+	}
+
+	// Set default values if not provided
+	if args.Target == "" {
+		args.Target = activities.NexusTargetCloud
+	}
+
+	// Validate required fields
+	if args.Spec == nil {
+		return nil, temporal.NewNonRetryableApplicationError("spec is required", "ValidationError", nil)
+	}
+	if err := args.Spec.Validate(); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if args.Target != activities.NexusTargetCloud && args.Target != activities.NexusTargetLocal {
+		return nil, temporal.NewNonRetryableApplicationError("target must be cloud or local", "ValidationError", nil)
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions)
+	logger := workflow.GetLogger(ctx)
+	logger.Info("CreateNexusEndpoint workflow started",
+		"target", args.Target,
+		"name", args.Spec.Name,
+		"targetNamespace", args.Spec.TargetNamespace,
+		"targetTaskQueue", args.Spec.TargetTaskQueue,
+	)
+
+	if args.Target == activities.NexusTargetCloud {
+		// the target and every caller namespace have to exist for the endpoint to be usable
+		namespaces := append([]string{args.Spec.TargetNamespace}, args.Spec.AllowedCallerNamespaces...)
+		if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.ValidateNamespaces, &activities.ValidateNamespacesRequest{
+			Namespaces: namespaces,
+		}).Get(ctx, nil); err != nil {
+			return nil, err
+		}
+	} else if len(args.Spec.AllowedCallerNamespaces) > 0 {
+		logger.Warn("Allowed caller namespaces are only enforced by Temporal Cloud and are ignored for local endpoints")
+	}
+
+	if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.CreateNexusEndpoint, &activities.CreateNexusEndpointRequest{
+		Target: args.Target,
+		Spec:   args.Spec,
+	}).Get(ctx, &state.Created); err != nil {
+		return nil, err
+	}
+	if err := awaitAsyncOperation(ctx, state.Created.AsyncOperationId); err != nil {
+		return nil, err
+	}
+
+	logger.Info("CreateNexusEndpoint workflow completed successfully", "endpointId", state.Created.EndpointId)
+	return state.Created, nil
+}
@@ -1,6 +1,12 @@
 package workflows
 
 import (
+	"fmt"
+
+	"temporal-jumpstart-operations/clientconfig"
+	"temporal-jumpstart-operations/notify"
+	"temporal-jumpstart-operations/ownership"
+	"temporal-jumpstart-operations/policy"
 	"temporal-jumpstart-operations/workflows/activities"
 
 	"go.temporal.io/sdk/temporal"
@@ -10,29 +16,94 @@ import (
 type CreateOperationsServiceAccountState struct {
 	Args           *CreateServiceAccountRequest
 	ServiceAccount *activities.CreateServiceAccountResponse
+	APIKey         *activities.CreateAPIKeyResponse
+	ClientConfig   *activities.WriteClientConfigResponse
 }
 
 // CreateServiceAccountRequest represents the parameters for creating a service account
 type CreateServiceAccountRequest struct {
-	// OutputPath is the path where configuration files will be generated (required)
+	// OutputPath is the secret sink URI the API key is written to, e.g. a directory or vault+https://... (required)
 	OutputPath string `json:"outputPath"`
 
+	// ConfigPath is the directory configuration files are generated in (optional, defaults to the OutputPath directory)
+	ConfigPath string `json:"configPath"`
+
+	// Recipients are age or OpenPGP public keys the API key file is encrypted to (optional)
+	Recipients []string `json:"recipients"`
+
 	// ServiceAccountName is the name of the service account to create (required)
 	ServiceAccountName string `json:"serviceAccountName"`
 
+	// Description is the service account description (optional, defaults to 'Service account for temporal jumpstart operations - {service_account_name}')
+	Description string `json:"description"`
+
 	// APIKeyName is the name of the API key to create (optional, defaults to {service_account_name}_key)
 	APIKeyName string `json:"apiKeyName"`
 
+	// APIKeyDescription is the API key description (optional, defaults to 'API key for service account {service_account_name}')
+	APIKeyDescription string `json:"apiKeyDescription"`
+
 	// Duration is the duration for the API key (optional, defaults to '1y')
 	Duration string `json:"duration"`
+
+	// AccountRole is the account level role of the service account (optional, defaults to 'read')
+	AccountRole string `json:"accountRole"`
+
+	// NamespacePermissions maps each namespace the service account can reach to admin, write or read (optional)
+	NamespacePermissions map[string]string `json:"namespacePermissions"`
+
+	// LocalAddress is the host:port of the persistent local TemporalService written as the 'local' profile (optional)
+	LocalAddress string `json:"localAddress"`
+
+	// Output selects the generated formats: envconfig, secret, sealed-secret, external-secret, kustomize (optional)
+	Output *clientconfig.OutputOptions `json:"output,omitempty"`
+
+	// ApprovalRequest names the requester and whether the starter's policy requires approval
+	ApprovalRequest
+
+	// Owner is the team, contact, cost center and tickets recorded in the description's owner tag (optional)
+	Owner *ownership.Owner `json:"owner,omitempty"`
+}
+
+// Identities returns the service account and API key as the policy sees them, before descriptions are defaulted
+func (r *CreateServiceAccountRequest) Identities() *policy.Identities {
+	return &policy.Identities{
+		ServiceAccounts: []policy.ServiceAccount{{
+			Name:        r.ServiceAccountName,
+			Description: r.Description,
+			AccountRole: r.AccountRole,
+		}},
+		ApiKeys: []policy.ApiKey{{
+			ServiceAccountName: r.ServiceAccountName,
+			Name:               r.APIKeyName,
+			Description:        r.APIKeyDescription,
+			Duration:           r.Duration,
+		}},
+	}
+}
+
+// Access returns the service account access described by the request
+func (r *CreateServiceAccountRequest) Access() *activities.Access {
+	return &activities.Access{
+		AccountRole:          r.AccountRole,
+		NamespacePermissions: r.NamespacePermissions,
+	}
 }
 
 // CreateOperationsServiceAccount is a Temporal workflow that creates a service account
 // and associated API key in Temporal Cloud for jumpstart operations
-func CreateOperationsServiceAccount(ctx workflow.Context, args *CreateServiceAccountRequest) error {
+func CreateOperationsServiceAccount(ctx workflow.Context, args *CreateServiceAccountRequest) (err error) {
 	state := &CreateOperationsServiceAccountState{
 		Args: args,
 	}
+	defer func() {
+		notifyOutcome(ctx, &notify.Event{
+			Operation: "create service account",
+			Subject:   args.ServiceAccountName,
+			Team:      args.Owner.TeamName(),
+			Details:   map[string]string{"account role": args.AccountRole},
+		}, err)
+	}()
 
 	// Set default values if not provided
 	if args.APIKeyName == "" {
@@ -41,6 +112,9 @@ func CreateOperationsServiceAccount(ctx workflow.Context, args *CreateServiceAcc
 	if args.Duration == "" {
 		args.Duration = "1y"
 	}
+	if args.AccountRole == "" {
+		args.AccountRole = "read"
+	}
 
 	// Validate required fields
 	if args.OutputPath == "" {
@@ -49,40 +123,109 @@ func CreateOperationsServiceAccount(ctx workflow.Context, args *CreateServiceAcc
 	if args.ServiceAccountName == "" {
 		return temporal.NewNonRetryableApplicationError("serviceAccountName is required", "ValidationError", nil)
 	}
+	if _, err := args.Access().ToIdentityAccess(); err != nil {
+		return temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if err := args.Output.Validate(); err != nil {
+		return temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+	if err := args.Owner.Validate(); err != nil {
+		return temporal.NewNonRetryableApplicationError(err.Error(), "ValidationError", err)
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions)
+
+	// The output path is checked on the worker, since sinks read recipient files and environment variables
+	var output *activities.ResolveSecretOutputResponse
+	if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.ResolveSecretOutput, &activities.ResolveSecretOutputRequest{
+		OutputPath: args.OutputPath,
+		ConfigPath: args.ConfigPath,
+		Recipients: args.Recipients,
+		ApiKeyName: args.APIKeyName,
+		Output:     args.Output,
+	}).Get(ctx, &output); err != nil {
+		return err
+	}
+	args.ConfigPath = output.ConfigPath
+
+	// The worker enforces its own policy; the workflow input has no say in it
+	if err := checkPolicy(ctx, args.Identities()); err != nil {
+		return err
+	}
 
-	// TODO: Implement workflow logic
-	// This workflow should orchestrate the following activities:
-	// 1. Create service account in Temporal Cloud
-	// 2. Create API key for the service account
-	// 3. Initialize local TemporalService
-	// 4. Generate configuration files
-	// 5. Save configuration to output path
+	// Descriptions are defaulted after the policy check so a required description cannot be satisfied by the default
+	if args.Description == "" {
+		args.Description = fmt.Sprintf("Service account for temporal jumpstart operations - %s", args.ServiceAccountName)
+	}
+	if args.APIKeyDescription == "" {
+		args.APIKeyDescription = fmt.Sprintf("API key for service account %s", args.ServiceAccountName)
+	}
+	args.Description = ownership.EncodeDescription(args.Description, args.Owner)
 
 	workflow.GetLogger(ctx).Info("CreateOperationsServiceAccount workflow started",
 		"outputPath", args.OutputPath,
 		"serviceAccountName", args.ServiceAccountName,
 		"apiKeyName", args.APIKeyName,
 		"duration", args.Duration,
+		"access", args.Access().String(),
 	)
 
-	// TODO: Execute activities to create service account
+	// A service account that cannot reach its namespaces is useless, so check them before creating anything
+	if namespaces := args.Access().Namespaces(); len(namespaces) > 0 {
+		if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.ValidateNamespaces, &activities.ValidateNamespacesRequest{
+			Namespaces: namespaces,
+		}).Get(ctx, nil); err != nil {
+			return err
+		}
+	}
+
+	if policy.IsPrivilegedAccountRole(args.AccountRole) {
+		operation := fmt.Sprintf("create service account %s with account role %s", args.ServiceAccountName, args.AccountRole)
+		if err := awaitApproval(ctx, args.ApprovalRequest, operation); err != nil {
+			return err
+		}
+	}
+
 	if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.CreateServiceAccount, &activities.CreateServiceAccountRequest{
 		Name:        args.ServiceAccountName,
-		Description: "Service account for operations",
+		Description: args.Description,
+		Access:      args.Access(),
 	}).Get(ctx, &state.ServiceAccount); err != nil {
 		return err
 	}
+	if err := awaitAsyncOperation(ctx, state.ServiceAccount.AsyncOperationId); err != nil {
+		return err
+	}
 
 	if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.CreateAPIKey, &activities.CreateAPIKeyRequest{
 		ServiceAccountId: state.ServiceAccount.ServiceAccountId,
 		Name:             args.APIKeyName,
+		Description:      args.APIKeyDescription,
 		Duration:         args.Duration,
 		OutputPath:       args.OutputPath,
+		ConfigPath:       args.ConfigPath,
+		Recipients:       args.Recipients,
+		Output:           args.Output,
 	}).Get(ctx, &state.APIKey); err != nil {
 		return err
 	}
+	if err := awaitAsyncOperation(ctx, state.APIKey.AsyncOperationId); err != nil {
+		return err
+	}
+
+	// Secrets sent to a remote sink have no local directory to put configuration next to
+	if args.ConfigPath == "" {
+		workflow.GetLogger(ctx).Info("No configPath for the secret sink, skipping client config", "secret", state.APIKey.SecretLocation)
+	} else if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.WriteClientConfig, &activities.WriteClientConfigRequest{
+		OutputPath:   args.ConfigPath,
+		ApiKeyFile:   output.ApiKeyFile,
+		Namespaces:   args.Access().Namespaces(),
+		LocalAddress: args.LocalAddress,
+		Output:       args.Output,
+	}).Get(ctx, &state.ClientConfig); err != nil {
+		return err
+	}
 
-	// Placeholder for workflow implementation
 	workflow.GetLogger(ctx).Info("CreateOperationsServiceAccount workflow completed successfully")
 
 	return nil
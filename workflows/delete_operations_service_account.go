@@ -0,0 +1,80 @@
+package workflows
+
+import (
+	"fmt"
+
+	"temporal-jumpstart-operations/notify"
+	"temporal-jumpstart-operations/ownership"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// DeleteServiceAccountRequest represents the parameters for deleting a service account
+type DeleteServiceAccountRequest struct {
+	// ServiceAccountName is the name of the service account to delete (required)
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// ApprovalRequest names the requester and whether the starter's policy requires approval
+	ApprovalRequest
+
+	// Owner routes the notification when the description has no owner tag, e.g. from the local registry (optional)
+	Owner *ownership.Owner `json:"owner,omitempty"`
+}
+
+// DeleteServiceAccountResult reports the deleted service account
+type DeleteServiceAccountResult struct {
+	ServiceAccountId string `json:"serviceAccountId"`
+	// Owner is the owner tag of the deleted service account's description
+	Owner *ownership.Owner `json:"owner,omitempty"`
+}
+
+// DeleteOperationsServiceAccount is a Temporal workflow that deletes a service account, and its API keys with it,
+// once the deletion is approved when the worker's policy requires approval on its Cloud account
+func DeleteOperationsServiceAccount(ctx workflow.Context, args *DeleteServiceAccountRequest) (result *DeleteServiceAccountResult, err error) {
+	defer func() {
+		owner := args.Owner
+		if result != nil {
+			owner = result.Owner.Merge(owner)
+		}
+		notifyOutcome(ctx, &notify.Event{
+			Operation: "delete service account",
+			Subject:   args.ServiceAccountName,
+			Team:      owner.TeamName(),
+		}, err)
+	}()
+
+	// Validate required fields
+	if args.ServiceAccountName == "" {
+		return nil, temporal.NewNonRetryableApplicationError("serviceAccountName is required", "ValidationError", nil)
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions)
+	workflow.GetLogger(ctx).Info("DeleteOperationsServiceAccount workflow started", "serviceAccountName", args.ServiceAccountName)
+
+	// Approvers sign off on a service account that exists, not just on a name
+	var found *activities.GetServiceAccountResponse
+	if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.GetServiceAccount, &activities.GetServiceAccountRequest{
+		Name: args.ServiceAccountName,
+	}).Get(ctx, &found); err != nil {
+		return nil, err
+	}
+	operation := fmt.Sprintf("delete service account %s (%s)", args.ServiceAccountName, found.ServiceAccountId)
+	if err := awaitApproval(ctx, args.ApprovalRequest, operation); err != nil {
+		return nil, err
+	}
+
+	var resp *activities.DeleteServiceAccountResponse
+	if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.DeleteServiceAccount, &activities.DeleteServiceAccountRequest{
+		Name: args.ServiceAccountName,
+	}).Get(ctx, &resp); err != nil {
+		return nil, err
+	}
+	if err := awaitAsyncOperation(ctx, resp.AsyncOperationId); err != nil {
+		return nil, err
+	}
+
+	workflow.GetLogger(ctx).Info("DeleteOperationsServiceAccount workflow completed successfully")
+	return &DeleteServiceAccountResult{ServiceAccountId: resp.ServiceAccountId, Owner: resp.Owner}, nil
+}
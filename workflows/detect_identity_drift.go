@@ -0,0 +1,62 @@
+package workflows
+
+import (
+	"time"
+
+	"temporal-jumpstart-operations/desiredstate"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// DetectIdentityDriftRequest represents the parameters for a drift detection run
+type DetectIdentityDriftRequest struct {
+	// DesiredStatePath is the desired state file Cloud identities are compared against (required)
+	DesiredStatePath string `json:"desiredStatePath"`
+
+	// ReportPath is the directory drift reports are written to (required)
+	ReportPath string `json:"reportPath"`
+
+	// MaxKeyLifetime is the longest lifetime an API key may have (optional, defaults to '1y')
+	MaxKeyLifetime string `json:"maxKeyLifetime"`
+}
+
+// DetectIdentityDrift is a Temporal workflow that compares Temporal Cloud service accounts, API keys
+// and namespace access against the desired state and writes a drift report.
+// It is meant to run on a Temporal Schedule, see `operations drift schedule`.
+func DetectIdentityDrift(ctx workflow.Context, args *DetectIdentityDriftRequest) (*desiredstate.DetectDriftResponse, error) {
+	// Set default values if not provided
+	if args.MaxKeyLifetime == "" {
+		args.MaxKeyLifetime = "1y"
+	}
+
+	// Validate required fields
+	if args.DesiredStatePath == "" {
+		return nil, temporal.NewNonRetryableApplicationError("desiredStatePath is required", "ValidationError", nil)
+	}
+	if args.ReportPath == "" {
+		return nil, temporal.NewNonRetryableApplicationError("reportPath is required", "ValidationError", nil)
+	}
+
+	// listing every identity in a large account can take a while
+	ao := defaultActivityOptions
+	ao.StartToCloseTimeout = 10 * time.Minute
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var result *desiredstate.DetectDriftResponse
+	if err := workflow.ExecuteActivity(ctx, desiredstate.TypeActivities.DetectDrift, &desiredstate.DetectDriftRequest{
+		DesiredStatePath: args.DesiredStatePath,
+		ReportPath:       args.ReportPath,
+		MaxKeyLifetime:   args.MaxKeyLifetime,
+	}).Get(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	logger := workflow.GetLogger(ctx)
+	if result.Findings > 0 {
+		logger.Warn("Identity drift detected", "findings", result.Findings, "summary", result.Summary, "report", result.ReportFile)
+	} else {
+		logger.Info("No identity drift detected", "report", result.ReportFile)
+	}
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"temporal-jumpstart-operations/notify"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ExportCloudAuditLogsRequest represents the parameters for exporting the Temporal Cloud account audit log
+type ExportCloudAuditLogsRequest struct {
+	// Sink is where records are shipped: a JSONL file, an http(s) URL or s3://bucket/prefix (required)
+	Sink string `json:"sink"`
+
+	// Lookback is how far back the first run starts, e.g. '7d' (optional, defaults to '1d', at most 30 days are kept)
+	Lookback string `json:"lookback,omitempty"`
+
+	// PageSize is the number of records requested per page (optional, defaults to 100)
+	PageSize int32 `json:"pageSize,omitempty"`
+
+	// Cursor is where the export resumes (optional, defaults to where the previous scheduled run stopped)
+	Cursor *activities.AuditLogCursor `json:"cursor,omitempty"`
+}
+
+// ExportCloudAuditLogsResult reports the progress of the export
+type ExportCloudAuditLogsResult struct {
+	Cursor   activities.AuditLogCursor `json:"cursor"`
+	Exported int                       `json:"exported"`
+}
+
+// ExportCloudAuditLogs is a Temporal workflow that pages through the Cloud account audit log up to now and ships
+// the records to a sink. The cursor is checkpointed in workflow state after every activity. Run from a Temporal
+// Schedule, every run resumes from the cursor of the last run that completed.
+func ExportCloudAuditLogs(ctx workflow.Context, args *ExportCloudAuditLogsRequest) (result *ExportCloudAuditLogsResult, err error) {
+	defer func() {
+		details := map[string]string{}
+		if result != nil {
+			details["exported"] = fmt.Sprint(result.Exported)
+		}
+		notifyOutcome(ctx, &notify.Event{Operation: "export cloud audit logs", Subject: args.Sink, Details: details}, err)
+	}()
+
+	// Set default values if not provided
+	if args.Lookback == "" {
+		args.Lookback = "1d"
+	}
+
+	// Validate required fields
+	if args.Sink == "" {
+		return nil, temporal.NewNonRetryableApplicationError("sink is required", "ValidationError", nil)
+	}
+	lookback, err := activities.ParseDuration(args.Lookback)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError("invalid lookback", "ValidationError", err)
+	}
+
+	// a page of a large account's audit log can take a while to ship
+	ao := defaultActivityOptions
+	ao.StartToCloseTimeout = 10 * time.Minute
+	ao.HeartbeatTimeout = 2 * time.Minute
+	ctx = workflow.WithActivityOptions(ctx, ao)
+	logger := workflow.GetLogger(ctx)
+
+	result = &ExportCloudAuditLogsResult{}
+	switch {
+	case args.Cursor != nil:
+		result.Cursor = *args.Cursor
+	case workflow.HasLastCompletionResult(ctx):
+		var last ExportCloudAuditLogsResult
+		if err := workflow.GetLastCompletionResult(ctx, &last); err != nil {
+			return nil, err
+		}
+		result.Cursor = last.Cursor
+	default:
+		result.Cursor.After = workflow.Now(ctx).Add(-lookback)
+	}
+
+	for {
+		var resp *activities.ExportAuditLogsResponse
+		if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.ExportAuditLogs, &activities.ExportAuditLogsRequest{
+			Sink:     args.Sink,
+			Cursor:   result.Cursor,
+			PageSize: args.PageSize,
+		}).Get(ctx, &resp); err != nil {
+			return result, err
+		}
+		result.Cursor = resp.Cursor
+		result.Exported += resp.Exported
+		if resp.CaughtUp {
+			break
+		}
+	}
+	logger.Info("Cloud audit log exported", "exported", result.Exported, "cursor", result.Cursor.After)
+	return result, nil
+}
@@ -0,0 +1,139 @@
+package workflows
+
+import (
+	"errors"
+
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	UserImportInvited  = "invited"
+	UserImportUpdated  = "updated"
+	UserImportExisting = "existing"
+	UserImportFailed   = "failed"
+)
+
+// UserImportRow is a single user to onboard
+type UserImportRow struct {
+	// Email is the user email the invitation is sent to (required)
+	Email string `json:"email"`
+
+	// AccountRole is the account level role of the user (optional, defaults to 'read' for new users;
+	// existing users keep their role unless it is set)
+	AccountRole string `json:"accountRole"`
+
+	// NamespacePermissions maps each namespace the user can reach to admin, write or read (optional)
+	NamespacePermissions map[string]string `json:"namespacePermissions"`
+
+	// Groups are the user groups the user is added to (optional)
+	Groups []string `json:"groups"`
+}
+
+// ImportUsersRequest represents the parameters for onboarding users in bulk
+type ImportUsersRequest struct {
+	// Users are the users to invite or update (required)
+	Users []UserImportRow `json:"users"`
+}
+
+// UserImportResult is the outcome for a single row
+type UserImportResult struct {
+	Email  string `json:"email"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportUsersResult reports the outcome of every row, in input order
+type ImportUsersResult struct {
+	Results []UserImportResult `json:"results"`
+}
+
+// ImportUsers is a Temporal workflow that invites users, assigns their roles and group memberships.
+// Users that already exist only get the account role and namespace permissions their row sets,
+// and group memberships they already have are left alone, so an import can be re-run safely.
+// A failing row is recorded and does not stop the remaining rows.
+func ImportUsers(ctx workflow.Context, args *ImportUsersRequest) (*ImportUsersResult, error) {
+	// Validate required fields
+	if len(args.Users) == 0 {
+		return nil, temporal.NewNonRetryableApplicationError("users are required", "ValidationError", nil)
+	}
+	for _, row := range args.Users {
+		if row.Email == "" {
+			return nil, temporal.NewNonRetryableApplicationError("email is required for every user", "ValidationError", nil)
+		}
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions)
+	logger := workflow.GetLogger(ctx)
+	logger.Info("ImportUsers workflow started", "users", len(args.Users))
+
+	result := &ImportUsersResult{}
+	for _, row := range args.Users {
+		status, err := importUser(ctx, row)
+		if err != nil {
+			logger.Warn("Failed to import user", "email", row.Email, "error", err)
+			result.Results = append(result.Results, UserImportResult{Email: row.Email, Status: UserImportFailed, Error: err.Error()})
+			continue
+		}
+		result.Results = append(result.Results, UserImportResult{Email: row.Email, Status: status})
+	}
+
+	logger.Info("ImportUsers workflow completed")
+	return result, nil
+}
+
+// importUser invites or updates a single user and adds it to its groups
+func importUser(ctx workflow.Context, row UserImportRow) (string, error) {
+	access := &activities.Access{
+		AccountRole:          row.AccountRole,
+		NamespacePermissions: row.NamespacePermissions,
+	}
+
+	status := UserImportInvited
+	var resp *activities.UserOperationResponse
+	err := workflow.ExecuteActivity(ctx, activities.TypeActivities.InviteUser, &activities.InviteUserRequest{
+		Email:  row.Email,
+		Access: access,
+	}).Get(ctx, &resp)
+
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) && appErr.Type() == "already exists" {
+		if row.AccountRole == "" && len(row.NamespacePermissions) == 0 {
+			return UserImportExisting, addUserGroupMemberships(ctx, row)
+		}
+		status = UserImportUpdated
+		err = workflow.ExecuteActivity(ctx, activities.TypeActivities.SetUserAccess, &activities.SetUserAccessRequest{
+			Email:  row.Email,
+			Access: access,
+		}).Get(ctx, &resp)
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := awaitAsyncOperation(ctx, resp.AsyncOperationId); err != nil {
+		return "", err
+	}
+	if err := addUserGroupMemberships(ctx, row); err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// addUserGroupMemberships adds the user to every group of its row
+func addUserGroupMemberships(ctx workflow.Context, row UserImportRow) error {
+	for _, group := range row.Groups {
+		var groupResp *activities.UserGroupOperationResponse
+		if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.AddUserGroupMember, &activities.UserGroupMemberRequest{
+			GroupName: group,
+			Email:     row.Email,
+		}).Get(ctx, &groupResp); err != nil {
+			return err
+		}
+		if err := awaitAsyncOperation(ctx, groupResp.AsyncOperationId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package workflows
+
+import (
+	"time"
+
+	"temporal-jumpstart-operations/notify"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// notifyActivityOptions give up on a notification quickly; an unreachable webhook must not hold a workflow open
+var notifyActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: 30 * time.Second,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2,
+		MaximumAttempts:    3,
+	},
+}
+
+// notifyOutcome tells the worker's notifiers how the workflow ended. Child workflows are reported by their parent,
+// continuing as new is not an outcome, and a failed notification is logged without failing the workflow.
+// The event carries the operation, subject, team and details; the outcome and workflow are filled in here.
+func notifyOutcome(ctx workflow.Context, event *notify.Event, err error) {
+	info := workflow.GetInfo(ctx)
+	if info.ParentWorkflowExecution != nil || workflow.IsContinueAsNewError(err) {
+		return
+	}
+
+	event.Status = notify.StatusSucceeded
+	event.WorkflowID = info.WorkflowExecution.ID
+	event.RunID = info.WorkflowExecution.RunID
+	event.Time = workflow.Now(ctx)
+	if err != nil {
+		event.Status = notify.StatusFailed
+		event.Error = err.Error()
+	}
+
+	// A cancelled workflow is still reported
+	ctx, _ = workflow.NewDisconnectedContext(ctx)
+	ctx = workflow.WithActivityOptions(ctx, notifyActivityOptions)
+	if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.Notify, &activities.NotifyRequest{
+		Event: event,
+	}).Get(ctx, nil); err != nil {
+		workflow.GetLogger(ctx).Warn("Failed to send notification", "operation", event.Operation, "error", err)
+	}
+}
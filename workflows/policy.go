@@ -0,0 +1,13 @@
+package workflows
+
+import (
+	"temporal-jumpstart-operations/policy"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+// checkPolicy runs the identities past the policy of the worker, which fails the workflow on any violation
+func checkPolicy(ctx workflow.Context, identities *policy.Identities) error {
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions)
+	return workflow.ExecuteActivity(ctx, policy.TypeActivities.CheckIdentities, identities).Get(ctx, nil)
+}
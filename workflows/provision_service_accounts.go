@@ -0,0 +1,167 @@
+package workflows
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"temporal-jumpstart-operations/notify"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Provisioning outcomes of a single manifest row
+const (
+	ProvisionStatusCreated = "created"
+	ProvisionStatusExists  = "exists"
+	ProvisionStatusFailed  = "failed"
+)
+
+// ProvisionServiceAccountsRequest represents the parameters for creating many service accounts at once
+type ProvisionServiceAccountsRequest struct {
+	// Accounts are the service accounts to create, one CreateOperationsServiceAccount child workflow each (required)
+	Accounts []*CreateServiceAccountRequest `json:"accounts"`
+
+	// MaxConcurrent bounds the child workflows running at the same time (optional, defaults to 5)
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+
+	// BatchSize is how many accounts a run provisions before continuing as new (optional, defaults to 50)
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// Offset is the index of the first account this run provisions, carried across continue-as-new
+	Offset int `json:"offset,omitempty"`
+
+	// Results are the results of the previous runs, carried across continue-as-new
+	Results []ProvisionResult `json:"results,omitempty"`
+}
+
+// ProvisionResult is the outcome of one manifest row
+type ProvisionResult struct {
+	// Row is the index of the account in the request
+	Row int `json:"row"`
+	// ServiceAccountName is the service account the row creates
+	ServiceAccountName string `json:"serviceAccountName"`
+	// Status is created, exists or failed
+	Status string `json:"status"`
+	// Error explains a failed row
+	Error string `json:"error,omitempty"`
+	// WorkflowID is the child workflow that provisioned the row
+	WorkflowID string `json:"workflowId"`
+}
+
+// ProvisionServiceAccountsResult reports the outcome of every row
+type ProvisionServiceAccountsResult struct {
+	Results []ProvisionResult `json:"results"`
+	Created int               `json:"created"`
+	Exists  int               `json:"exists"`
+	Failed  int               `json:"failed"`
+}
+
+// ProvisionServiceAccounts is a Temporal workflow that fans out a CreateOperationsServiceAccount child workflow
+// per account, at most MaxConcurrent at a time. A failing row is recorded and does not stop the others.
+// Every BatchSize accounts the workflow continues as new so large manifests keep a bounded history.
+func ProvisionServiceAccounts(ctx workflow.Context, args *ProvisionServiceAccountsRequest) (summary *ProvisionServiceAccountsResult, err error) {
+	defer func() {
+		details := map[string]string{"accounts": fmt.Sprint(len(args.Accounts))}
+		if summary != nil {
+			details["created"] = fmt.Sprint(summary.Created)
+			details["exists"] = fmt.Sprint(summary.Exists)
+			details["failed"] = fmt.Sprint(summary.Failed)
+		}
+		notifyOutcome(ctx, &notify.Event{Operation: "provision service accounts", Details: details}, err)
+	}()
+
+	// Set default values if not provided
+	if args.MaxConcurrent <= 0 {
+		args.MaxConcurrent = 5
+	}
+	if args.BatchSize <= 0 {
+		args.BatchSize = 50
+	}
+
+	// Validate required fields
+	if len(args.Accounts) == 0 {
+		return nil, temporal.NewNonRetryableApplicationError("accounts are required", "ValidationError", nil)
+	}
+	for i, account := range args.Accounts {
+		if account == nil || account.ServiceAccountName == "" {
+			return nil, temporal.NewNonRetryableApplicationError(fmt.Sprintf("account %d: serviceAccountName is required", i), "ValidationError", nil)
+		}
+	}
+
+	logger := workflow.GetLogger(ctx)
+	parentID := workflow.GetInfo(ctx).WorkflowExecution.ID
+	end := min(args.Offset+args.BatchSize, len(args.Accounts))
+	logger.Info("ProvisionServiceAccounts workflow started", "accounts", len(args.Accounts), "from", args.Offset, "to", end)
+
+	results := args.Results
+	selector := workflow.NewSelector(ctx)
+	running := 0
+	for next := args.Offset; next < end || running > 0; {
+		for ; next < end && running < args.MaxConcurrent; next++ {
+			row, account := next, args.Accounts[next]
+			childID := fmt.Sprintf("%s-%s", parentID, account.ServiceAccountName)
+			childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+				WorkflowID:        childID,
+				ParentClosePolicy: enumspb.PARENT_CLOSE_POLICY_REQUEST_CANCEL,
+			})
+			future := workflow.ExecuteChildWorkflow(childCtx, CreateOperationsServiceAccount, account)
+			selector.AddFuture(future, func(f workflow.Future) {
+				result := ProvisionResult{
+					Row:                row,
+					ServiceAccountName: account.ServiceAccountName,
+					Status:             ProvisionStatusCreated,
+					WorkflowID:         childID,
+				}
+				if err := f.Get(ctx, nil); err != nil {
+					result.Status, result.Error = provisionFailure(err)
+				}
+				logger.Info("Service account provisioned", "serviceAccount", result.ServiceAccountName, "status", result.Status)
+				results = append(results, result)
+			})
+			running++
+		}
+		selector.Select(ctx)
+		running--
+	}
+
+	if end < len(args.Accounts) {
+		next := *args
+		next.Offset = end
+		next.Results = results
+		return nil, workflow.NewContinueAsNewError(ctx, ProvisionServiceAccounts, &next)
+	}
+
+	// Children complete in any order, the report follows the manifest
+	slices.SortFunc(results, func(a, b ProvisionResult) int { return a.Row - b.Row })
+	summary = &ProvisionServiceAccountsResult{Results: results}
+	for _, result := range results {
+		switch result.Status {
+		case ProvisionStatusCreated:
+			summary.Created++
+		case ProvisionStatusExists:
+			summary.Exists++
+		default:
+			summary.Failed++
+		}
+	}
+	logger.Info("ProvisionServiceAccounts workflow completed", "created", summary.Created, "exists", summary.Exists, "failed", summary.Failed)
+	return summary, nil
+}
+
+// provisionFailure classifies a failed child workflow; a service account that already exists is not an error
+func provisionFailure(err error) (string, string) {
+	var activityErr *temporal.ActivityError
+	var appErr *temporal.ApplicationError
+	if !errors.As(err, &appErr) {
+		return ProvisionStatusFailed, err.Error()
+	}
+	if appErr.Message() == activities.ERR_ALREADY_EXISTS && errors.As(err, &activityErr) &&
+		activityErr.ActivityType().GetName() == "CreateServiceAccount" {
+		return ProvisionStatusExists, ""
+	}
+	return ProvisionStatusFailed, appErr.Message()
+}
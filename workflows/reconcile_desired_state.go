@@ -0,0 +1,158 @@
+package workflows
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"temporal-jumpstart-operations/desiredstate"
+	"temporal-jumpstart-operations/notify"
+	"temporal-jumpstart-operations/workflows/activities"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ReconcileDesiredStateRequest represents the parameters for converging Cloud to a desired state
+type ReconcileDesiredStateRequest struct {
+	// Plan is the plan computed by `operations apply` against the current Cloud state (required)
+	Plan *desiredstate.Plan `json:"plan"`
+	// ApprovalRequest names the requester and whether the starter's policy requires approval
+	ApprovalRequest
+}
+
+// ReconcileDesiredStateResult reports what the reconciliation did
+type ReconcileDesiredStateResult struct {
+	// ServiceAccountIds maps each created or updated service account name to its id
+	ServiceAccountIds map[string]string `json:"serviceAccountIds"`
+	// ApiKeyIds maps each created api key name to its id
+	ApiKeyIds map[string]string `json:"apiKeyIds"`
+}
+
+type ReconcileDesiredStateState struct {
+	Args   *ReconcileDesiredStateRequest
+	Result *ReconcileDesiredStateResult
+}
+
+// ReconcileDesiredState is a Temporal workflow that applies a desired state plan to Temporal Cloud.
+// Actions run in plan order so service accounts exist before the api keys they own.
+func ReconcileDesiredState(ctx workflow.Context, args *ReconcileDesiredStateRequest) (result *ReconcileDesiredStateResult, err error) {
+	state := &ReconcileDesiredStateState{
+		Args: args,
+		Result: &ReconcileDesiredStateResult{
+			ServiceAccountIds: map[string]string{},
+			ApiKeyIds:         map[string]string{},
+		},
+	}
+	defer func() {
+		details := map[string]string{}
+		if args.Plan != nil {
+			details["actions"] = fmt.Sprint(len(args.Plan.Actions))
+		}
+		notifyOutcome(ctx, &notify.Event{Operation: "apply desired state", Details: details}, err)
+	}()
+
+	// Validate required fields
+	if args.Plan == nil {
+		return nil, temporal.NewNonRetryableApplicationError("plan is required", "ValidationError", nil)
+	}
+	if len(args.Plan.Conflicts) > 0 {
+		return nil, temporal.NewNonRetryableApplicationError(
+			"plan has conflicts: "+strings.Join(args.Plan.Conflicts, "; "), "ValidationError", nil)
+	}
+	if err := checkPolicy(ctx, args.Plan.Identities()); err != nil {
+		return nil, err
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions)
+	logger := workflow.GetLogger(ctx)
+	logger.Info("ReconcileDesiredState workflow started", "actions", len(args.Plan.Actions))
+
+	// Check every namespace the plan grants access to before mutating anything
+	var namespaces []string
+	for _, action := range args.Plan.Actions {
+		for _, ns := range action.Access.Namespaces() {
+			if !slices.Contains(namespaces, ns) {
+				namespaces = append(namespaces, ns)
+			}
+		}
+	}
+	if len(namespaces) > 0 {
+		if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.ValidateNamespaces, &activities.ValidateNamespacesRequest{
+			Namespaces: namespaces,
+		}).Get(ctx, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if privileged := args.Plan.Privileged(); len(privileged) > 0 {
+		if err := awaitApproval(ctx, args.ApprovalRequest, "apply "+strings.Join(privileged, ", ")); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, action := range args.Plan.Actions {
+		logger.Info("Applying action", "type", action.Type, "serviceAccount", action.ServiceAccountName, "reason", action.Reason)
+
+		switch action.Type {
+		case desiredstate.ActionCreateServiceAccount:
+			var resp *activities.CreateServiceAccountResponse
+			if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.CreateServiceAccount, &activities.CreateServiceAccountRequest{
+				Name:        action.ServiceAccountName,
+				Description: action.Description,
+				Access:      action.Access,
+			}).Get(ctx, &resp); err != nil {
+				return nil, err
+			}
+			if err := awaitAsyncOperation(ctx, resp.AsyncOperationId); err != nil {
+				return nil, err
+			}
+			state.Result.ServiceAccountIds[action.ServiceAccountName] = resp.ServiceAccountId
+
+		case desiredstate.ActionUpdateServiceAccount:
+			var resp *activities.UpdateServiceAccountResponse
+			if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.UpdateServiceAccount, &activities.UpdateServiceAccountRequest{
+				ServiceAccountId: action.ServiceAccountId,
+				Description:      action.Description,
+				Access:           action.Access,
+			}).Get(ctx, &resp); err != nil {
+				return nil, err
+			}
+			if err := awaitAsyncOperation(ctx, resp.AsyncOperationId); err != nil {
+				return nil, err
+			}
+			state.Result.ServiceAccountIds[action.ServiceAccountName] = resp.ServiceAccountId
+
+		case desiredstate.ActionCreateApiKey:
+			serviceAccountId := action.ServiceAccountId
+			if serviceAccountId == "" {
+				serviceAccountId = state.Result.ServiceAccountIds[action.ServiceAccountName]
+			}
+			if serviceAccountId == "" {
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("service account %s has no id for api key %s", action.ServiceAccountName, action.ApiKey.Name), "ValidationError", nil)
+			}
+			var resp *activities.CreateAPIKeyResponse
+			if err := workflow.ExecuteActivity(ctx, activities.TypeActivities.CreateAPIKey, &activities.CreateAPIKeyRequest{
+				ServiceAccountId: serviceAccountId,
+				Name:             action.ApiKey.Name,
+				Description:      action.ApiKey.Description,
+				Duration:         action.ApiKey.Duration,
+				OutputPath:       action.ApiKey.OutputPath,
+			}).Get(ctx, &resp); err != nil {
+				return nil, err
+			}
+			if err := awaitAsyncOperation(ctx, resp.AsyncOperationId); err != nil {
+				return nil, err
+			}
+			state.Result.ApiKeyIds[action.ApiKey.Name] = resp.ApiKeyId
+
+		default:
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("unknown action type %s", action.Type), "ValidationError", nil)
+		}
+	}
+
+	logger.Info("ReconcileDesiredState workflow completed successfully")
+	return state.Result, nil
+}